@@ -8,7 +8,10 @@ import (
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/ppapapetrou76/virtual-assistant/pkg/actions/assigner"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/actions/commands"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/actions/conflict"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/actions/labeler"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/actions/labelsync"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
 )
@@ -36,6 +39,9 @@ func main() {
 	merr := new(multierror.Error)
 	merr = multierror.Append(merr, labeler.New(cfg, repo).HandleEvent(eventName, eventPayload))
 	merr = multierror.Append(merr, assigner.New(cfg, repo).HandleEvent(eventName, eventPayload))
+	merr = multierror.Append(merr, labelsync.New(cfg, repo).HandleEvent(eventName, eventPayload))
+	merr = multierror.Append(merr, conflict.New(cfg, repo).HandleEvent(eventName, eventPayload))
+	merr = multierror.Append(merr, commands.New(cfg, repo).HandleEvent(eventName, eventPayload))
 	checkErr(merr.ErrorOrNil())
 }
 
@@ -12,6 +12,8 @@ import (
 type Assigner struct {
 	*config.AssignerConfig
 	github.Repo
+	// unavailable holds the lower case logins of the unavailable users once they are loaded
+	unavailable map[string]bool
 }
 
 // HandleEvent takes a GitHub Event and its raw payload (see link below)
@@ -28,10 +30,36 @@ func (l *Assigner) HandleEvent(eventName string, payload *[]byte) error {
 		if actions.ShouldRunOnPullRequest(event, l.IssuesAssignerConfig.Actions) {
 			err = l.runOnPR(event.PullRequest)
 		}
+		if err == nil && projectActions.HasString(event.GetAction()) {
+			err = l.addPullRequestToProject(github.NewIssue(l.Repo, event.PullRequest.GetNumber()))
+		}
+		if err == nil && event.GetAction() == "opened" {
+			err = l.moveLinkedIssues(event.PullRequest)
+		}
+		if err == nil && (event.GetAction() != "closed" || event.PullRequest.GetMerged()) {
+			err = l.assignMilestone(event.PullRequest.GetNumber(), event.PullRequest.Milestone,
+				config.PullRequestsTarget, event.GetAction(), event.PullRequest.GetBase().GetRef())
+		}
+		if err == nil && reviewActions.HasString(event.GetAction()) {
+			err = l.requestReviewers(event.PullRequest)
+			if err == nil {
+				err = l.assignCodeOwners(event.PullRequest)
+			}
+		}
 	case *gh.IssuesEvent:
 		if actions.ShouldRunOnIssue(event, l.IssuesAssignerConfig.Actions) {
 			err = l.runOnIssue(event.Issue)
 		}
+		if err == nil {
+			err = l.moveCard(event.Issue, event.GetAction())
+		}
+		if err == nil {
+			err = l.assignMilestone(event.Issue.GetNumber(), event.Issue.Milestone, config.IssuesTarget,
+				event.GetAction(), "")
+		}
+		if err == nil && ownerActions.HasString(event.GetAction()) {
+			err = l.assignOwners(event.Issue, event.Label)
+		}
 	}
 	return err
 }
@@ -41,12 +69,19 @@ func (l *Assigner) runOnPR(i *gh.PullRequest) error {
 	if !l.Assignee.Auto {
 		return nil
 	}
+	available, err := l.isAvailable(i.GetUser().GetLogin())
+	if err != nil || !available {
+		return err
+	}
 	return issue.AddAssignee()
 }
 
 func (l *Assigner) runOnIssue(i *gh.Issue) error {
-	issue := github.NewIssue(l.Repo, *i.Number)
-	return issue.AddToProject(l.AssignerConfig.ProjectURL, l.AssignerConfig.Column)
+	project, err := l.issueProject(i)
+	if err != nil || !project.IsEnabled() {
+		return err
+	}
+	return l.addToProject(github.NewIssue(l.Repo, *i.Number), project)
 }
 
 // New creates a new labeler object
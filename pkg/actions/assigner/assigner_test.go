@@ -59,6 +59,8 @@ func TestAssigner_HandleEvent(t *testing.T) {
 					github.MockGetIssueResponse(),
 					github.MockListRepositoryProjectsResponse(),
 					github.MockListProjectColumnsResponse(),
+					github.MockListEmptyProjectCardsResponse(),
+					github.MockListEmptyProjectCardsResponse(),
 					github.MockGenericSuccessResponse(),
 				}),
 			}),
@@ -137,8 +139,10 @@ func issueAssigner(repo github.Repo) Assigner {
 		AssignerConfig: &config.AssignerConfig{
 			IssuesAssignerConfig: config.IssuesAssignerConfig{
 				IssuesAssignerProjectConfig: config.IssuesAssignerProjectConfig{
-					ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1",
-					Column:     "To Do",
+					ProjectConfig: config.ProjectConfig{
+						ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1",
+						Column:     "To Do",
+					},
 				},
 				Actions: []string{"opened"},
 			},
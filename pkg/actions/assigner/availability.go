@@ -0,0 +1,81 @@
+package assigner
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-yaml/yaml"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+)
+
+// now returns the current time. It's a variable so that tests can fix the date
+var now = time.Now
+
+// isAvailable returns false if the given user is unavailable today according to the inline and the file based
+// availability configuration
+func (l *Assigner) isAvailable(user string) (bool, error) {
+	if l.unavailable == nil {
+		unavailable, err := l.unavailableUsers()
+		if err != nil {
+			return false, err
+		}
+		l.unavailable = unavailable
+	}
+	return !l.unavailable[strings.ToLower(user)], nil
+}
+
+// unavailableUsers returns the lower case logins of the users that are unavailable today
+func (l *Assigner) unavailableUsers() (map[string]bool, error) {
+	cfg := l.AssignerConfig.Availability
+	schedules := []map[string][]config.DateRange{cfg.Unavailable}
+	if cfg.File != "" {
+		content, err := l.Repo.LoadFile(cfg.File, "")
+		if err != nil {
+			return nil, err
+		}
+		var fromFile map[string][]config.DateRange
+		if err := yaml.Unmarshal(*content, &fromFile); err != nil {
+			return nil, fmt.Errorf("cannot parse availability file %s. error message : %s", cfg.File, err.Error())
+		}
+		schedules = append(schedules, fromFile)
+	}
+
+	today := now()
+	unavailable := map[string]bool{}
+	for _, schedule := range schedules {
+		for user, ranges := range schedule {
+			for _, r := range ranges {
+				included, err := r.Includes(today)
+				if err != nil {
+					return nil, fmt.Errorf("cannot check availability of %s. error message : %s", user, err.Error())
+				}
+				if included {
+					log.Printf("%s is unavailable today", user)
+					unavailable[strings.ToLower(strings.TrimPrefix(user, "@"))] = true
+				}
+			}
+		}
+	}
+	return unavailable, nil
+}
+
+// availableReviewers returns the given candidates except for the unavailable users
+func (l *Assigner) availableReviewers(candidates []reviewer) ([]reviewer, error) {
+	available := make([]reviewer, 0, len(candidates))
+	for _, c := range candidates {
+		if !c.Team {
+			ok, err := l.isAvailable(c.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		available = append(available, c)
+	}
+	return available, nil
+}
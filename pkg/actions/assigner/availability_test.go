@@ -0,0 +1,112 @@
+package assigner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+const availabilityFile = `monalisa:
+  - from: 2020-12-20
+    to: 2021-01-05
+hubot:
+  - from: 2020-11-01
+`
+
+func fixNow(t *testing.T, date string) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = func() time.Time { return day.Add(15 * time.Hour) }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestAssigner_availableReviewers(t *testing.T) {
+	candidates := []reviewer{{Name: "octocat"}, {Name: "Monalisa"}, {Name: "hubot"}, {Name: "backend", Team: true}}
+	tests := []struct {
+		name          string
+		config        config.AvailabilityConfig
+		responses     []github.MockResponse
+		expected      []reviewer
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:     "should keep everyone if nobody is unavailable",
+			expected: candidates,
+		},
+		{
+			name: "should skip the users that are unavailable today",
+			config: config.AvailabilityConfig{
+				Unavailable: map[string][]config.DateRange{
+					"@octocat": {{From: "2020-12-01", To: "2020-12-24"}},
+					"hubot":    {{From: "2020-12-25"}},
+				},
+				File: ".github/availability.yml",
+			},
+			responses: []github.MockResponse{
+				github.MockGetContentsResponse(availabilityFile),
+			},
+			expected: []reviewer{{Name: "hubot"}, {Name: "backend", Team: true}},
+		},
+		{
+			name: "should error if a date cannot be parsed",
+			config: config.AvailabilityConfig{
+				Unavailable: map[string][]config.DateRange{
+					"octocat": {{From: "24/12/2020"}},
+				},
+			},
+			wantErr:       true,
+			expectedError: errors.New(`cannot check availability of octocat. error message : cannot parse date 24/12/2020. error message : parsing time "24/12/2020" as "2006-01-02": cannot parse "24/12/2020" as "2006"`),
+		},
+		{
+			name:   "should error if the availability file cannot be parsed",
+			config: config.AvailabilityConfig{File: ".github/availability.yml"},
+			responses: []github.MockResponse{
+				github.MockGetContentsResponse("- octocat"),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot parse availability file .github/availability.yml. error message : yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into map[string][]config.DateRange"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixNow(t, "2020-12-24")
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{Availability: tt.config},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			actual, err := assigner.availableReviewers(candidates)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !tt.wantErr && !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAssigner_runOnPR_unavailableAuthor(t *testing.T) {
+	fixNow(t, "2020-12-24")
+	assigner := prAssigner(github.Repo{
+		GHClient: github.MockGithubClient(nil),
+		Owner:    "ppapapetrou76",
+		Name:     "virtual-assistant",
+	})
+	assigner.IssuesAssignerConfig.Actions = []string{"opened"}
+	assigner.Availability.Unavailable = map[string][]config.DateRange{
+		"octocat": {{From: "2020-12-24"}},
+	}
+	payload := []byte(reviewPayload("opened", false))
+	err := assigner.HandleEvent("pull_request", &payload)
+	testutil.AssertError(t, false, nil, err)
+}
@@ -0,0 +1,144 @@
+package assigner
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/codeowners"
+)
+
+// codeOwnersPaths are the locations of the CODEOWNERS file in the order GitHub looks them up
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// assignCodeOwners assigns the given pull request to and/or requests its reviews from the owners of its changed files.
+// The author of the pull request is never picked and draft pull requests are skipped until they are ready for review
+func (l *Assigner) assignCodeOwners(pr *gh.PullRequest) error {
+	cfg := l.PullRequestsAssignerConfig.CodeOwners
+	if !cfg.Assign && !cfg.Review {
+		return nil
+	}
+	if pr.GetDraft() {
+		log.Printf("Pull request #%d is a draft. Skipping code owners", pr.GetNumber())
+		return nil
+	}
+
+	rules, err := l.loadCodeOwners(pr.GetHead().GetSHA())
+	if err != nil || rules == nil {
+		return err
+	}
+	pullRequest := github.NewIssue(l.Repo, pr.GetNumber())
+	files, err := pullRequest.Files()
+	if err != nil {
+		return err
+	}
+
+	owners, err := l.resolveOwners(fileOwners(rules, files), cfg.ExpandTeams, pr.GetUser().GetLogin())
+	if err != nil {
+		return err
+	}
+	if owners, err = l.availableReviewers(owners); err != nil {
+		return err
+	}
+	if cfg.Max > 0 && len(owners) > cfg.Max {
+		owners = owners[:cfg.Max]
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+
+	var users, teams []string
+	for _, owner := range owners {
+		if owner.Team {
+			teams = append(teams, owner.Name)
+			continue
+		}
+		users = append(users, owner.Name)
+	}
+	merr := new(multierror.Error)
+	if cfg.Review {
+		merr = multierror.Append(merr, pullRequest.RequestReviewers(users, teams))
+	}
+	if cfg.Assign && len(users) > 0 {
+		merr = multierror.Append(merr, pullRequest.AddAssignees(users...))
+	}
+	return merr.ErrorOrNil()
+}
+
+// loadCodeOwners loads and parses the CODEOWNERS file of the repository at the given commit. It returns no rules if
+// the repository has no CODEOWNERS file
+func (l *Assigner) loadCodeOwners(sha string) (codeowners.Rules, error) {
+	for _, path := range codeOwnersPaths {
+		content, err := l.Repo.LoadFile(path, sha)
+		if github.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return codeowners.Parse(string(*content)), nil
+	}
+	log.Printf("No CODEOWNERS file found in %s/%s@%s. Skipping code owners", l.Owner, l.Name, sha)
+	return nil, nil
+}
+
+// fileOwners returns the owners of the given files, the ones that own more files first. Owners of the same number of
+// files keep the order they are first found in
+func fileOwners(rules codeowners.Rules, files []*gh.CommitFile) []string {
+	var owners []string
+	counts := map[string]int{}
+	for _, f := range files {
+		for _, owner := range rules.Owners(f.GetFilename()) {
+			if counts[owner] == 0 {
+				owners = append(owners, owner)
+			}
+			counts[owner]++
+		}
+	}
+	sort.SliceStable(owners, func(i, j int) bool {
+		return counts[owners[i]] > counts[owners[j]]
+	})
+	return owners
+}
+
+// resolveOwners converts the given CODEOWNERS owners to users and teams, expanding the teams to their members if
+// needed. Email owners can't be resolved to users so they are skipped, as well as the given author and duplicates
+func (l *Assigner) resolveOwners(owners []string, expandTeams bool, author string) ([]reviewer, error) {
+	var resolved []reviewer
+	seen := map[string]bool{}
+	add := func(r reviewer) {
+		if (!r.Team && strings.EqualFold(r.Name, author)) || seen[r.key()] {
+			return
+		}
+		seen[r.key()] = true
+		resolved = append(resolved, r)
+	}
+	for _, owner := range owners {
+		if !strings.HasPrefix(owner, "@") {
+			continue
+		}
+		owner = strings.TrimPrefix(owner, "@")
+		i := strings.Index(owner, "/")
+		if i < 0 {
+			add(reviewer{Name: owner})
+			continue
+		}
+		org, slug := owner[:i], owner[i+1:]
+		if !expandTeams {
+			add(reviewer{Name: slug, Team: true})
+			continue
+		}
+		members, err := l.Repo.TeamMembers(org, slug)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			add(reviewer{Name: member})
+		}
+	}
+	return resolved, nil
+}
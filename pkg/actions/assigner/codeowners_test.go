@@ -0,0 +1,146 @@
+package assigner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/codeowners"
+)
+
+const codeOwnersFile = `*           @hubot
+docs/       @octocat @myorg/docs docs@example.com
+pkg/github/ @monalisa @myorg/backend
+`
+
+func TestFileOwners(t *testing.T) {
+	files := []*gh.CommitFile{
+		{Filename: gh.String("README.md")},
+		{Filename: gh.String("pkg/github/issue.go")},
+		{Filename: gh.String("pkg/github/repo.go")},
+		{Filename: gh.String("docs/README.md")},
+	}
+	expected := []string{"@monalisa", "@myorg/backend", "@hubot", "@octocat", "@myorg/docs", "docs@example.com"}
+	actual := fileOwners(codeowners.Parse(codeOwnersFile), files)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expect: \n%+v Got: \n%+v", expected, actual)
+	}
+}
+
+func TestAssigner_resolveOwners(t *testing.T) {
+	owners := []string{"@octocat", "@myorg/backend", "docs@example.com", "@hubot"}
+	tests := []struct {
+		name        string
+		expandTeams bool
+		responses   []github.MockResponse
+		expected    []reviewer
+	}{
+		{
+			name:     "should keep the teams and skip the author and the emails",
+			expected: []reviewer{{Name: "backend", Team: true}, {Name: "hubot"}},
+		},
+		{
+			name:        "should expand the teams to their members",
+			expandTeams: true,
+			responses: []github.MockResponse{
+				github.MockGetTeamResponse(),
+				github.MockListTeamMembersResponse(),
+			},
+			expected: []reviewer{{Name: "monalisa"}, {Name: "hubot"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			actual, err := assigner.resolveOwners(owners, tt.expandTeams, "octocat")
+			testutil.AssertError(t, false, nil, err)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAssigner_assignCodeOwners(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		config        config.CodeOwnersConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:    "should do nothing if code owners are not enabled",
+			payload: reviewPayload("opened", false),
+		},
+		{
+			name:    "should do nothing if the pull request is a draft",
+			payload: reviewPayload("opened", true),
+			config:  config.CodeOwnersConfig{Review: true},
+		},
+		{
+			name:    "should do nothing if there is no CODEOWNERS file",
+			payload: reviewPayload("opened", false),
+			config:  config.CodeOwnersConfig{Review: true},
+			responses: []github.MockResponse{
+				github.MockNotFoundResponse(),
+				github.MockNotFoundResponse(),
+				github.MockNotFoundResponse(),
+			},
+		},
+		{
+			name:    "should request reviews from and assign the code owners up to the max",
+			payload: reviewPayload("ready_for_review", false),
+			config:  config.CodeOwnersConfig{Review: true, Assign: true, Max: 2},
+			responses: []github.MockResponse{
+				github.MockNotFoundResponse(),
+				github.MockGetContentsResponse(codeOwnersFile),
+				github.MockListPullRequestFilesResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:    "should error if the CODEOWNERS file cannot be loaded",
+			payload: reviewPayload("opened", false),
+			config:  config.CodeOwnersConfig{Assign: true},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("load file : unable to load file from ppapapetrou76/virtual-assistant@/.github/CODEOWNERS: GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/contents/.github/CODEOWNERS: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					PullRequestsAssignerConfig: config.PullRequestsAssignerConfig{
+						CodeOwners: tt.config,
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent("pull_request", &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
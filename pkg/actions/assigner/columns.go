@@ -0,0 +1,60 @@
+package assigner
+
+import (
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+)
+
+// linkedPullRequestColumn is the key of the column mapping for the issues that a newly opened pull request resolves
+const linkedPullRequestColumn = "linked-pull-request"
+
+// moveCard moves the project card of the given issue to the column mapped to the given event action, if any. The
+// column is the status of the issue in a project (v2)
+func (l *Assigner) moveCard(i *gh.Issue, action string) error {
+	column, ok := l.IssuesAssignerProjectConfig.Columns[action]
+	if !ok {
+		return nil
+	}
+	project, err := l.issueProject(i)
+	if err != nil || !project.IsEnabled() {
+		return err
+	}
+	return l.moveToColumn(github.NewIssue(l.Repo, i.GetNumber()), project, column)
+}
+
+// moveLinkedIssues moves the project cards of the issues of this repository that the given pull request resolves
+// (e.g. `Fixes #123`) to the column mapped to linked pull requests, if any
+func (l *Assigner) moveLinkedIssues(pr *gh.PullRequest) error {
+	cfg := l.IssuesAssignerProjectConfig
+	column, ok := cfg.Columns[linkedPullRequestColumn]
+	if !ok || (!cfg.IsEnabled() && len(cfg.Targets) == 0) {
+		return nil
+	}
+	merr := new(multierror.Error)
+	for _, issue := range github.NewIssue(l.Repo, pr.GetNumber()).LinkedIssues(pr.GetBody()) {
+		// the project belongs to this repository so issues of other repositories can't be moved
+		if !strings.EqualFold(issue.Owner, l.Owner) || !strings.EqualFold(issue.Name, l.Name) {
+			continue
+		}
+		project := cfg.ProjectConfig
+		if len(cfg.Targets) > 0 {
+			// the targets are matched against the issue itself rather than the pull request
+			i, err := issue.Get()
+			if err == nil {
+				project, err = l.issueProject(i)
+			}
+			if err != nil {
+				merr = multierror.Append(merr, err)
+				continue
+			}
+		}
+		if project.IsEnabled() {
+			merr = multierror.Append(merr, l.moveToColumn(issue, project, column))
+		}
+	}
+	return merr.ErrorOrNil()
+}
@@ -0,0 +1,124 @@
+package assigner
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func issueActionPayload(action string) string {
+	return fmt.Sprintf(`{
+  "action": "%s",
+  "issue": {
+    "number": 1347,
+    "title": "Found a bug"
+  }
+}`, action)
+}
+
+const linkedPullRequestPayload = `{
+  "action": "opened",
+  "number": 2,
+  "pull_request": {
+    "number": 2,
+    "state": "open",
+    "draft": true,
+    "body": "Fixes #1347 and closes octo-org/octo-repo#3"
+  }
+}`
+
+func TestAssigner_moveCard(t *testing.T) {
+	project := config.IssuesAssignerProjectConfig{
+		ProjectConfig: config.ProjectConfig{
+			ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1",
+		},
+		Columns: map[string]string{
+			"closed":                "Done",
+			"reopened":              "To Do",
+			linkedPullRequestColumn: "Done",
+		},
+	}
+	tests := []struct {
+		name          string
+		eventName     string
+		payload       string
+		project       config.IssuesAssignerProjectConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should do nothing if no project is configured",
+			eventName: "issues",
+			payload:   issueActionPayload("closed"),
+			project:   config.IssuesAssignerProjectConfig{Columns: project.Columns},
+		},
+		{
+			name:      "should do nothing if the action has no column",
+			eventName: "issues",
+			payload:   issueActionPayload("labeled"),
+			project:   project,
+		},
+		{
+			name:      "should move the card of a closed issue",
+			eventName: "issues",
+			payload:   issueActionPayload("closed"),
+			project:   project,
+			responses: []github.MockResponse{
+				github.MockGetIssueResponse(),
+				github.MockListRepositoryProjectsResponse(),
+				github.MockListProjectColumnsResponse(),
+				github.MockListProjectCardsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should move the cards of the issues of this repository that a new pull request resolves",
+			eventName: "pull_request",
+			payload:   linkedPullRequestPayload,
+			project:   project,
+			responses: []github.MockResponse{
+				github.MockGetIssueResponse(),
+				github.MockListRepositoryProjectsResponse(),
+				github.MockListProjectColumnsResponse(),
+				github.MockListProjectCardsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should error if the card cannot be moved",
+			eventName: "issues",
+			payload:   issueActionPayload("reopened"),
+			project:   project,
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get issue with number 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					IssuesAssignerConfig: config.IssuesAssignerConfig{
+						IssuesAssignerProjectConfig: tt.project,
+						Actions:                     []string{"milestoned"},
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
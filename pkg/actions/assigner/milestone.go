@@ -0,0 +1,111 @@
+package assigner
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const defaultBranchPrefix = "release/"
+
+// defaultMilestoneActions are the event actions that set the milestone if none are configured
+var defaultMilestoneActions = slices.StringSlice{"opened"}
+
+// assignMilestone sets the milestone of the given issue or pull request, according to the configured strategy, unless
+// it already has one. The base branch is empty for issues
+func (l *Assigner) assignMilestone(number int, current *gh.Milestone, target, action, base string) error {
+	cfg := l.AssignerConfig.Milestone
+	// issues have no base branch to get the milestone from
+	if !cfg.AppliesTo(target) || (cfg.Strategy == config.BaseBranchMilestone && target == config.IssuesTarget) {
+		return nil
+	}
+	actions := cfg.Actions
+	if actions.IsEmpty() {
+		actions = defaultMilestoneActions
+	}
+	if !actions.HasString(action) {
+		return nil
+	}
+	if current != nil {
+		log.Printf("#%d is already in milestone %s. Skipping milestone", number, current.GetTitle())
+		return nil
+	}
+
+	milestone, err := l.milestone(base)
+	if err != nil || milestone == nil {
+		return err
+	}
+	return github.NewIssue(l.Repo, number).SetMilestone(milestone.GetNumber())
+}
+
+// milestone returns the milestone of the configured strategy or nil if there is none
+func (l *Assigner) milestone(base string) (*gh.Milestone, error) {
+	cfg := l.AssignerConfig.Milestone
+	var name string
+	switch cfg.Strategy {
+	case config.NextDueMilestone:
+		return l.nextDueMilestone()
+	case config.FixedMilestone:
+		name = cfg.Name
+	case config.BaseBranchMilestone:
+		prefix := cfg.BranchPrefix
+		if prefix == "" {
+			prefix = defaultBranchPrefix
+		}
+		if !strings.HasPrefix(base, prefix) {
+			log.Printf("Base branch `%s` doesn't start with `%s`. Skipping milestone", base, prefix)
+			return nil, nil
+		}
+		name = strings.TrimPrefix(base, prefix)
+	default:
+		return nil, fmt.Errorf("cannot set milestone. error message : unknown milestone strategy %s", cfg.Strategy)
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	milestones, err := l.Repo.Milestones("all")
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range milestones {
+		if m.GetTitle() == name {
+			return m, nil
+		}
+	}
+	if !cfg.Create {
+		log.Printf("No milestone found with name %s. Skipping milestone", name)
+		return nil, nil
+	}
+	return l.Repo.CreateMilestone(name)
+}
+
+// nextDueMilestone returns the open milestone with the closest due date that hasn't passed. Milestones without a due
+// date are ignored
+func (l *Assigner) nextDueMilestone() (*gh.Milestone, error) {
+	milestones, err := l.Repo.Milestones("open")
+	if err != nil {
+		return nil, err
+	}
+	today := now().UTC().Truncate(24 * time.Hour)
+	var next *gh.Milestone
+	for _, m := range milestones {
+		if m.DueOn == nil || m.GetDueOn().Before(today) {
+			continue
+		}
+		if next == nil || m.GetDueOn().Before(next.GetDueOn()) {
+			next = m
+		}
+	}
+	if next == nil {
+		log.Printf("No open milestone with an upcoming due date. Skipping milestone")
+	}
+	return next, nil
+}
@@ -0,0 +1,206 @@
+package assigner
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func milestonePayload(action, base string, merged bool, milestone string) string {
+	return fmt.Sprintf(`{
+  "action": "%s",
+  "number": 2,
+  "pull_request": {
+    "number": 2,
+    "state": "open",
+    "draft": true,
+    "merged": %t,
+    "base": {"ref": "%s"},
+    "milestone": %s
+  }
+}`, action, merged, base, milestone)
+}
+
+func TestAssigner_assignMilestone(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventName     string
+		payload       string
+		config        config.MilestoneConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should do nothing if no strategy is configured",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "main", false, "null"),
+		},
+		{
+			name:      "should do nothing if the target doesn't match",
+			eventName: "issues",
+			payload:   issueActionPayload("opened"),
+			config:    config.MilestoneConfig{Strategy: config.FixedMilestone, Name: "1.4", Target: config.PullRequestsTarget},
+		},
+		{
+			name:      "should do nothing if the action doesn't match",
+			eventName: "pull_request",
+			payload:   milestonePayload("synchronize", "main", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.FixedMilestone, Name: "1.4"},
+		},
+		{
+			name:      "should do nothing if there is already a milestone",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "main", false, `{"number": 1, "title": "1.3"}`),
+			config:    config.MilestoneConfig{Strategy: config.FixedMilestone, Name: "1.4"},
+		},
+		{
+			name:      "should do nothing if a closed pull request is not merged",
+			eventName: "pull_request",
+			payload:   milestonePayload("closed", "main", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.FixedMilestone, Name: "1.4", Actions: []string{"closed"}},
+		},
+		{
+			name:      "should set the fixed milestone to merged pull requests",
+			eventName: "pull_request",
+			payload:   milestonePayload("closed", "main", true, "null"),
+			config:    config.MilestoneConfig{Strategy: config.FixedMilestone, Name: "1.4", Actions: []string{"closed"}},
+			responses: []github.MockResponse{
+				github.MockListMilestonesResponse(),
+				github.MockGetIssueResponse(),
+			},
+		},
+		{
+			name:      "should set the next due milestone to new issues",
+			eventName: "issues",
+			payload:   issueActionPayload("opened"),
+			config:    config.MilestoneConfig{Strategy: config.NextDueMilestone},
+			responses: []github.MockResponse{
+				github.MockListMilestonesResponse(),
+				github.MockGetIssueResponse(),
+			},
+		},
+		{
+			name:      "should skip the issues with the base branch strategy",
+			eventName: "issues",
+			payload:   issueActionPayload("opened"),
+			config:    config.MilestoneConfig{Strategy: config.BaseBranchMilestone},
+		},
+		{
+			name:      "should set the milestone of the release base branch",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "release/1.4", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.BaseBranchMilestone},
+			responses: []github.MockResponse{
+				github.MockListMilestonesResponse(),
+				github.MockGetIssueResponse(),
+			},
+		},
+		{
+			name:      "should skip the base branches without the prefix",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "main", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.BaseBranchMilestone},
+		},
+		{
+			name:      "should skip the missing milestones",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "v1.5", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.BaseBranchMilestone, BranchPrefix: "v"},
+			responses: []github.MockResponse{
+				github.MockListMilestonesResponse(),
+			},
+		},
+		{
+			name:      "should create the missing milestones",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "v1.5", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.BaseBranchMilestone, BranchPrefix: "v", Create: true},
+			responses: []github.MockResponse{
+				github.MockListMilestonesResponse(),
+				github.MockCreateMilestoneResponse("1.5"),
+				github.MockGetIssueResponse(),
+			},
+		},
+		{
+			name:          "should error if the strategy is unknown",
+			eventName:     "pull_request",
+			payload:       milestonePayload("opened", "main", false, "null"),
+			config:        config.MilestoneConfig{Strategy: "latest"},
+			wantErr:       true,
+			expectedError: errors.New("cannot set milestone. error message : unknown milestone strategy latest"),
+		},
+		{
+			name:      "should error if the milestones cannot be retrieved",
+			eventName: "pull_request",
+			payload:   milestonePayload("opened", "main", false, "null"),
+			config:    config.MilestoneConfig{Strategy: config.FixedMilestone, Name: "1.4"},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get repository (ppapapetrou76/virtual-assistant) milestones. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/milestones?per_page=100&state=all: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixNow(t, "2020-12-24")
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{Milestone: tt.config},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestAssigner_nextDueMilestone(t *testing.T) {
+	tests := []struct {
+		name     string
+		today    string
+		expected string
+	}{
+		{
+			name:     "should return the milestone with the closest due date",
+			today:    "2020-11-20",
+			expected: "1.3",
+		},
+		{
+			name:     "should skip the milestones whose due date has passed",
+			today:    "2020-12-24",
+			expected: "1.4",
+		},
+		{
+			name:  "should return no milestone if all due dates have passed",
+			today: "2021-02-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixNow(t, tt.today)
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{github.MockListMilestonesResponse()}),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			milestone, err := assigner.nextDueMilestone()
+			testutil.AssertError(t, false, nil, err)
+			if actual := milestone.GetTitle(); actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package assigner
+
+import (
+	"log"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// ownerActions are the issues event actions that assign issues to the owners of their labels
+var ownerActions = slices.StringSlice{"opened", "labeled"}
+
+// assignOwners assigns the given issue to the owners of the given label or, if no label is given (i.e. the issue is
+// opened), to the owners of all its labels. Issues that are already assigned are left untouched
+func (l *Assigner) assignOwners(i *gh.Issue, label *gh.Label) error {
+	if len(l.IssuesAssignerConfig.Owners) == 0 {
+		return nil
+	}
+	if len(i.Assignees) > 0 {
+		log.Printf("Issue #%d is already assigned. Skipping owners assignment", i.GetNumber())
+		return nil
+	}
+
+	labels := i.Labels
+	if label != nil {
+		labels = []gh.Label{*label}
+	}
+	var users slices.StringSlice
+	for _, issueLabel := range labels {
+		owners, err := l.labelOwners(issueLabel.GetName(), i.GetNumber())
+		if err != nil {
+			return err
+		}
+		for _, owner := range owners {
+			if !users.HasString(owner) {
+				users = users.Add(owner)
+			}
+		}
+	}
+	if users.IsEmpty() {
+		return nil
+	}
+	return github.NewIssue(l.Repo, i.GetNumber()).AddAssignees(users...)
+}
+
+// labelOwners returns the available owners of the given label. Teams are expanded to their members and one of them is
+// picked in turn, based on the issue number, so that issues are spread evenly across the team
+func (l *Assigner) labelOwners(label string, number int) ([]string, error) {
+	var owners slices.StringSlice
+	for name, configured := range l.IssuesAssignerConfig.Owners {
+		if strings.EqualFold(name, label) {
+			owners = configured
+			break
+		}
+	}
+
+	var users []string
+	for _, owner := range owners {
+		owner = strings.TrimPrefix(owner, "@")
+		candidates := []string{owner}
+		i := strings.Index(owner, "/")
+		team := i >= 0
+		if team {
+			members, err := l.Repo.TeamMembers(owner[:i], owner[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			candidates = members
+		}
+
+		var available []string
+		for _, candidate := range candidates {
+			ok, err := l.isAvailable(candidate)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				available = append(available, candidate)
+			}
+		}
+		if len(available) == 0 {
+			log.Printf("No available owner of label %s in %s", label, owner)
+			continue
+		}
+		if team {
+			available = available[number%len(available) : number%len(available)+1]
+		}
+		users = append(users, available...)
+	}
+	return users, nil
+}
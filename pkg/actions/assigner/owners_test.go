@@ -0,0 +1,198 @@
+package assigner
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func labeledPayload(action, label, assignees string) string {
+	return fmt.Sprintf(`{
+  "action": "%s",
+  "label": {
+    "name": "%s"
+  },
+  "issue": {
+    "number": 3,
+    "title": "Some random issue",
+    "labels": [
+      {
+        "name": "area:parser"
+      },
+      {
+        "name": "area:infra"
+      }
+    ],
+    "assignees": [%s]
+  }
+}`, action, label, assignees)
+}
+
+const openedPayload = `{
+  "action": "opened",
+  "issue": {
+    "number": 3,
+    "title": "Some random issue",
+    "labels": [
+      {
+        "name": "area:parser"
+      },
+      {
+        "name": "Area:Infra"
+      }
+    ]
+  }
+}`
+
+var owners = map[string]slices.StringSlice{
+	"area:parser": {"@alice", "@bob"},
+	"area:infra":  {"@myorg/infra-team", "@alice"},
+}
+
+func TestAssigner_labelOwners(t *testing.T) {
+	tests := []struct {
+		name        string
+		label       string
+		number      int
+		unavailable map[string][]config.DateRange
+		responses   []github.MockResponse
+		expected    []string
+	}{
+		{
+			name:  "should return no owners for a label without owners",
+			label: "bug",
+		},
+		{
+			name:     "should return the users of the label",
+			label:    "area:parser",
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:   "should pick a team member in turn",
+			label:  "area:infra",
+			number: 3,
+			responses: []github.MockResponse{
+				github.MockGetTeamResponse(),
+				github.MockListTeamMembersResponse(),
+			},
+			expected: []string{"monalisa", "alice"},
+		},
+		{
+			name:   "should skip the unavailable owners",
+			label:  "AREA:INFRA",
+			number: 3,
+			unavailable: map[string][]config.DateRange{
+				"monalisa": {{From: "2020-12-24"}},
+				"alice":    {{From: "2020-12-24"}},
+			},
+			responses: []github.MockResponse{
+				github.MockGetTeamResponse(),
+				github.MockListTeamMembersResponse(),
+			},
+			expected: []string{"octocat"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixNow(t, "2020-12-24")
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					IssuesAssignerConfig: config.IssuesAssignerConfig{Owners: owners},
+					Availability:         config.AvailabilityConfig{Unavailable: tt.unavailable},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			actual, err := assigner.labelOwners(tt.label, tt.number)
+			testutil.AssertError(t, false, nil, err)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAssigner_assignOwners(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		owners        map[string]slices.StringSlice
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:    "should do nothing if no owners are configured",
+			payload: labeledPayload("labeled", "area:parser", ""),
+		},
+		{
+			name:    "should do nothing if the issue is already assigned",
+			payload: labeledPayload("labeled", "area:parser", `{"login": "octocat"}`),
+			owners:  owners,
+		},
+		{
+			name:    "should do nothing if the label has no owners",
+			payload: labeledPayload("labeled", "bug", ""),
+			owners:  owners,
+		},
+		{
+			name:    "should do nothing on other actions",
+			payload: labeledPayload("unlabeled", "area:parser", ""),
+			owners:  owners,
+		},
+		{
+			name:    "should assign the owners of the added label",
+			payload: labeledPayload("labeled", "area:parser", ""),
+			owners:  owners,
+			responses: []github.MockResponse{
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:    "should assign the owners of all labels of a new issue",
+			payload: openedPayload,
+			owners:  owners,
+			responses: []github.MockResponse{
+				github.MockGetTeamResponse(),
+				github.MockListTeamMembersResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:    "should error if the team members cannot be retrieved",
+			payload: labeledPayload("labeled", "area:infra", ""),
+			owners:  owners,
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get team myorg/infra-team. error message : GET https://api.github.com/orgs/myorg/teams/infra-team: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					IssuesAssignerConfig: config.IssuesAssignerConfig{Owners: tt.owners},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent("issues", &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
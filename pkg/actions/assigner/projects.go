@@ -0,0 +1,74 @@
+package assigner
+
+import (
+	"strings"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// statusField is the project (v2) field that stands for the column of a classic project
+const statusField = "Status"
+
+// projectActions are the pull request event actions that add the pull request to the configured project
+var projectActions = slices.StringSlice{"opened", "reopened"}
+
+// addToProject adds the given issue to the given project under its column. Issues added to a project (v2) also get
+// the configured field values
+func (l *Assigner) addToProject(issue github.Issue, cfg config.ProjectConfig) error {
+	if cfg.IsV2() {
+		project, err := l.Repo.GetProjectV2(cfg.Owner, cfg.Number)
+		if err != nil {
+			return err
+		}
+		return issue.AddToProjectV2(project, withStatus(cfg.Fields, cfg.Column))
+	}
+	return issue.AddToProject(cfg.ProjectURL, cfg.Column)
+}
+
+// addPullRequestToProject adds the given pull request to the configured pull requests project under its column, as
+// addToProject does for issues
+func (l *Assigner) addPullRequestToProject(pr github.Issue) error {
+	cfg := l.PullRequestsAssignerConfig.Project
+	if !cfg.IsEnabled() {
+		return nil
+	}
+	if cfg.IsV2() {
+		project, err := l.Repo.GetProjectV2(cfg.Owner, cfg.Number)
+		if err != nil {
+			return err
+		}
+		return pr.AddPullRequestToProjectV2(project, withStatus(cfg.Fields, cfg.Column))
+	}
+	return pr.AddPullRequestToProject(cfg.ProjectURL, cfg.Column)
+}
+
+// moveToColumn moves the given issue to the given column of the given project, or sets its status if it's a project
+// (v2). Issues that are not in the project yet are added to it
+func (l *Assigner) moveToColumn(issue github.Issue, cfg config.ProjectConfig, column string) error {
+	if cfg.IsV2() {
+		project, err := l.Repo.GetProjectV2(cfg.Owner, cfg.Number)
+		if err != nil {
+			return err
+		}
+		return issue.AddToProjectV2(project, withStatus(nil, column))
+	}
+	return issue.MoveToColumn(cfg.ProjectURL, column)
+}
+
+// withStatus returns a copy of the given field values with the given status, unless it's empty or the field values
+// already have a status
+func withStatus(fields map[string]string, status string) map[string]string {
+	result := make(map[string]string, len(fields)+1)
+	for name, value := range fields {
+		if strings.EqualFold(name, statusField) {
+			status = ""
+		}
+		result[name] = value
+	}
+	if status != "" {
+		result[statusField] = status
+	}
+	return result
+}
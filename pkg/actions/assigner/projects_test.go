@@ -0,0 +1,193 @@
+package assigner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func TestAssigner_ProjectV2(t *testing.T) {
+	project := config.IssuesAssignerProjectConfig{
+		ProjectConfig: config.ProjectConfig{
+			Owner:  "octo-org",
+			Number: 5,
+			Column: "Todo",
+			Fields: map[string]string{"Priority": "High", "Iteration": "Sprint 12"},
+		},
+		Columns: map[string]string{"closed": "Done"},
+	}
+	tests := []struct {
+		name          string
+		payload       string
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:    "should add new issues to the project with their status and fields",
+			payload: issueActionPayload("opened"),
+			responses: []github.MockResponse{
+				github.MockGetProjectV2Response(),
+				github.MockGetIssueResponse(),
+				github.MockAddProjectV2ItemResponse(),
+				github.MockUpdateProjectV2ItemFieldResponse(),
+				github.MockUpdateProjectV2ItemFieldResponse(),
+				github.MockUpdateProjectV2ItemFieldResponse(),
+			},
+		},
+		{
+			name:    "should set the status of closed issues",
+			payload: issueActionPayload("closed"),
+			responses: []github.MockResponse{
+				github.MockGetProjectV2Response(),
+				github.MockGetIssueResponse(),
+				github.MockAddProjectV2ItemResponse(),
+				github.MockUpdateProjectV2ItemFieldResponse(),
+			},
+		},
+		{
+			name:    "should error if the project cannot be found",
+			payload: issueActionPayload("opened"),
+			responses: []github.MockResponse{
+				github.MockGraphQLErrorResponse("Could not resolve to a ProjectV2 with the number 5."),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get project (octo-org/5). error message : Could not resolve to a ProjectV2 with the number 5."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					IssuesAssignerConfig: config.IssuesAssignerConfig{
+						IssuesAssignerProjectConfig: project,
+						Actions:                     []string{"opened"},
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent("issues", &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestAssigner_addPullRequestToProject(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		project       config.ProjectConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:    "should do nothing if no project is configured",
+			payload: reviewPayload("opened", true),
+		},
+		{
+			name:    "should do nothing if the action doesn't add pull requests to the project",
+			payload: reviewPayload("synchronize", false),
+			project: config.ProjectConfig{Owner: "octo-org", Number: 5},
+		},
+		{
+			name:    "should add reopened pull requests to the project column",
+			payload: reviewPayload("reopened", false),
+			project: config.ProjectConfig{
+				ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1",
+				Column:     "To Do",
+			},
+			responses: []github.MockResponse{
+				github.MockGetPullRequestResponse("true"),
+				github.MockListRepositoryProjectsResponse(),
+				github.MockListProjectColumnsResponse(),
+				github.MockListEmptyProjectCardsResponse(),
+				github.MockListEmptyProjectCardsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:    "should add new pull requests to the project (v2) with their status",
+			payload: reviewPayload("opened", true),
+			project: config.ProjectConfig{Owner: "octo-org", Number: 5, Column: "In Progress"},
+			responses: []github.MockResponse{
+				github.MockGetProjectV2Response(),
+				github.MockGetPullRequestResponse("true"),
+				github.MockAddProjectV2ItemResponse(),
+				github.MockUpdateProjectV2ItemFieldResponse(),
+			},
+		},
+		{
+			name:    "should error if the pull request cannot be added to the project",
+			payload: reviewPayload("opened", true),
+			project: config.ProjectConfig{Owner: "octo-org", Number: 5},
+			responses: []github.MockResponse{
+				github.MockGetProjectV2Response(),
+				github.MockGetPullRequestResponse("true"),
+				github.MockGraphQLErrorResponse("Resource not accessible by integration"),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot add issue (2) to project (PVT_kwDOAQ). error message : Resource not accessible by integration"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					PullRequestsAssignerConfig: config.PullRequestsAssignerConfig{Project: tt.project},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent("pull_request", &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestWithStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   map[string]string
+		status   string
+		expected map[string]string
+	}{
+		{
+			name:     "should add the status to the fields",
+			fields:   map[string]string{"Priority": "High"},
+			status:   "Todo",
+			expected: map[string]string{"Priority": "High", "Status": "Todo"},
+		},
+		{
+			name:     "should keep the status of the fields",
+			fields:   map[string]string{"status": "Backlog"},
+			status:   "Todo",
+			expected: map[string]string{"status": "Backlog"},
+		},
+		{
+			name:     "should not add an empty status",
+			fields:   map[string]string{"Priority": "High"},
+			expected: map[string]string{"Priority": "High"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := withStatus(tt.fields, tt.status); !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package assigner
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// reviewActions are the pull request event actions that request reviewers
+var reviewActions = slices.StringSlice{"opened", "ready_for_review"}
+
+// reviewer is the struct to represent a candidate reviewer, either a user or a team
+type reviewer struct {
+	Name string
+	Team bool
+}
+
+func (r reviewer) key() string {
+	if r.Team {
+		return "team:" + strings.ToLower(r.Name)
+	}
+	return strings.ToLower(r.Name)
+}
+
+// requestReviewers requests reviews of the given pull request from the configured pool of users and teams, based on
+// the configured strategy. The author of the pull request is never requested and draft pull requests are skipped until
+// they are ready for review
+func (l *Assigner) requestReviewers(pr *gh.PullRequest) error {
+	cfg := l.PullRequestsAssignerConfig.Reviewers
+	if !cfg.IsEnabled() {
+		return nil
+	}
+	if pr.GetDraft() {
+		log.Printf("Pull request #%d is a draft. Skipping reviewers request", pr.GetNumber())
+		return nil
+	}
+
+	candidates, err := l.availableReviewers(reviewerCandidates(cfg, pr.GetUser().GetLogin()))
+	if err != nil {
+		return err
+	}
+	count := cfg.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	var selected []reviewer
+	switch cfg.Strategy {
+	case "", config.RandomStrategy:
+		selected = randomReviewers(candidates, count)
+	case config.RoundRobinStrategy:
+		selected = roundRobinReviewers(candidates, count, pr.GetNumber())
+	case config.LeastOpenReviewsStrategy:
+		if selected, err = l.leastOpenReviewsReviewers(candidates, count); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot request reviewers of pull request %d. error message : unknown strategy %s",
+			pr.GetNumber(), cfg.Strategy)
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	var users, teams []string
+	for _, r := range selected {
+		if r.Team {
+			teams = append(teams, r.Name)
+			continue
+		}
+		users = append(users, r.Name)
+	}
+	return github.NewIssue(l.Repo, pr.GetNumber()).RequestReviewers(users, teams)
+}
+
+// reviewerCandidates returns the users and the teams of the pool, in this order, except for the given author
+func reviewerCandidates(cfg config.ReviewersConfig, author string) []reviewer {
+	candidates := make([]reviewer, 0, len(cfg.Users)+len(cfg.Teams))
+	for _, user := range cfg.Users {
+		user = strings.TrimPrefix(user, "@")
+		if !strings.EqualFold(user, author) {
+			candidates = append(candidates, reviewer{Name: user})
+		}
+	}
+	for _, team := range cfg.Teams {
+		candidates = append(candidates, reviewer{Name: team, Team: true})
+	}
+	return candidates
+}
+
+func randomReviewers(candidates []reviewer, count int) []reviewer {
+	selected := make([]reviewer, 0, count)
+	for _, i := range rand.Perm(len(candidates)) {
+		if len(selected) == count {
+			break
+		}
+		selected = append(selected, candidates[i])
+	}
+	return selected
+}
+
+// roundRobinReviewers picks the given number of consecutive candidates starting from the one at the position of the
+// pull request number, so that reviews rotate through the pool without storing any state
+func roundRobinReviewers(candidates []reviewer, count, number int) []reviewer {
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	selected := make([]reviewer, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, candidates[(number+i)%len(candidates)])
+	}
+	return selected
+}
+
+// leastOpenReviewsReviewers picks the given number of candidates with the fewest pending review requests on the open
+// pull requests of the repository. Candidates with the same number of requests keep their pool order
+func (l *Assigner) leastOpenReviewsReviewers(candidates []reviewer, count int) ([]reviewer, error) {
+	pullRequests, err := l.Repo.OpenPullRequests("")
+	if err != nil {
+		return nil, err
+	}
+	requests := map[string]int{}
+	for _, pr := range pullRequests {
+		for _, user := range pr.RequestedReviewers {
+			requests[reviewer{Name: user.GetLogin()}.key()]++
+		}
+		for _, team := range pr.RequestedTeams {
+			requests[reviewer{Name: team.GetSlug(), Team: true}.key()]++
+		}
+	}
+
+	sorted := append([]reviewer(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return requests[sorted[i].key()] < requests[sorted[j].key()]
+	})
+	if count > len(sorted) {
+		count = len(sorted)
+	}
+	return sorted[:count], nil
+}
@@ -0,0 +1,201 @@
+package assigner
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func reviewPayload(action string, draft bool) string {
+	return fmt.Sprintf(`{
+  "action": "%s",
+  "number": 2,
+  "pull_request": {
+    "id": 279147437,
+    "number": 2,
+    "state": "open",
+    "draft": %t,
+    "user": {
+      "login": "octocat"
+    }
+  }
+}`, action, draft)
+}
+
+func TestReviewerCandidates(t *testing.T) {
+	cfg := config.ReviewersConfig{
+		Users: []string{"@octocat", "hubot", "monalisa"},
+		Teams: []string{"backend"},
+	}
+	expected := []reviewer{{Name: "hubot"}, {Name: "monalisa"}, {Name: "backend", Team: true}}
+	actual := reviewerCandidates(cfg, "OctoCat")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expect: \n%+v Got: \n%+v", expected, actual)
+	}
+}
+
+func TestRoundRobinReviewers(t *testing.T) {
+	candidates := []reviewer{{Name: "hubot"}, {Name: "monalisa"}, {Name: "backend", Team: true}}
+	tests := []struct {
+		name     string
+		count    int
+		number   int
+		expected []reviewer
+	}{
+		{
+			name:     "should start from the position of the pull request number",
+			count:    1,
+			number:   4,
+			expected: []reviewer{{Name: "monalisa"}},
+		},
+		{
+			name:     "should wrap around the pool",
+			count:    2,
+			number:   2,
+			expected: []reviewer{{Name: "backend", Team: true}, {Name: "hubot"}},
+		},
+		{
+			name:     "should pick the whole pool if it's smaller than the count",
+			count:    5,
+			number:   3,
+			expected: candidates,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := roundRobinReviewers(candidates, tt.count, tt.number)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRandomReviewers(t *testing.T) {
+	candidates := []reviewer{{Name: "hubot"}, {Name: "monalisa"}, {Name: "backend", Team: true}}
+	actual := randomReviewers(candidates, 2)
+	if len(actual) != 2 || actual[0] == actual[1] {
+		t.Errorf("Expect 2 distinct reviewers Got: \n%+v", actual)
+	}
+	if actual := randomReviewers(candidates, 5); len(actual) != len(candidates) {
+		t.Errorf("Expect: \n%+v Got: \n%+v", len(candidates), len(actual))
+	}
+}
+
+func TestAssigner_leastOpenReviewsReviewers(t *testing.T) {
+	assigner := Assigner{
+		AssignerConfig: &config.AssignerConfig{},
+		Repo: github.Repo{
+			GHClient: github.MockGithubClient([]github.MockResponse{github.MockListPullRequestsResponse()}),
+			Owner:    "ppapapetrou76",
+			Name:     "virtual-assistant",
+		},
+	}
+	candidates := []reviewer{{Name: "octocat"}, {Name: "hubot"}, {Name: "backend", Team: true}, {Name: "monalisa"}}
+	expected := []reviewer{{Name: "monalisa"}, {Name: "hubot"}}
+	actual, err := assigner.leastOpenReviewsReviewers(candidates, 2)
+	testutil.AssertError(t, false, nil, err)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expect: \n%+v Got: \n%+v", expected, actual)
+	}
+}
+
+func TestAssigner_requestReviewers(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		config        config.ReviewersConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:    "should do nothing if no reviewers are configured",
+			payload: reviewPayload("opened", false),
+		},
+		{
+			name:    "should do nothing if the action is not eligible",
+			payload: reviewPayload("synchronize", false),
+			config:  config.ReviewersConfig{Users: []string{"hubot"}},
+		},
+		{
+			name:    "should do nothing if the pull request is a draft",
+			payload: reviewPayload("opened", true),
+			config:  config.ReviewersConfig{Users: []string{"hubot"}},
+		},
+		{
+			name:    "should do nothing if the author is the only candidate",
+			payload: reviewPayload("opened", false),
+			config:  config.ReviewersConfig{Users: []string{"octocat"}},
+		},
+		{
+			name:    "should request reviewers when the pull request is ready for review",
+			payload: reviewPayload("ready_for_review", false),
+			config: config.ReviewersConfig{
+				Users:    []string{"octocat", "hubot"},
+				Teams:    []string{"backend"},
+				Count:    2,
+				Strategy: config.RoundRobinStrategy,
+			},
+			responses: []github.MockResponse{
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:    "should request the reviewers with the least open reviews",
+			payload: reviewPayload("opened", false),
+			config: config.ReviewersConfig{
+				Users:    []string{"hubot", "monalisa"},
+				Strategy: config.LeastOpenReviewsStrategy,
+			},
+			responses: []github.MockResponse{
+				github.MockListPullRequestsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:    "should error if the strategy is unknown",
+			payload: reviewPayload("opened", false),
+			config: config.ReviewersConfig{
+				Users:    []string{"hubot"},
+				Strategy: "alphabetical",
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot request reviewers of pull request 2. error message : unknown strategy alphabetical"),
+		},
+		{
+			name:    "should error if the reviewers cannot be requested",
+			payload: reviewPayload("opened", false),
+			config:  config.ReviewersConfig{Users: []string{"hubot"}},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot request reviewers of pull request 2. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/2/requested_reviewers: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					PullRequestsAssignerConfig: config.PullRequestsAssignerConfig{
+						Reviewers: tt.config,
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent("pull_request", &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package assigner
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+)
+
+// issueProject returns the project of the given issue, i.e. the project of the first target that matches it or the
+// configured project if there are no targets. It returns an empty (disabled) project if no target matches
+func (l *Assigner) issueProject(i *gh.Issue) (config.ProjectConfig, error) {
+	cfg := l.IssuesAssignerProjectConfig
+	if len(cfg.Targets) == 0 {
+		return cfg.ProjectConfig, nil
+	}
+	for _, target := range cfg.Targets {
+		matched, err := targetMatches(target, i)
+		if err != nil {
+			return config.ProjectConfig{}, err
+		}
+		if matched {
+			return target.ProjectConfig, nil
+		}
+	}
+	log.Printf("No project target matches issue #%d. Skipping project", i.GetNumber())
+	return config.ProjectConfig{}, nil
+}
+
+// targetMatches returns true if the given issue matches all the conditions of the given target
+func targetMatches(target config.ProjectTarget, i *gh.Issue) (bool, error) {
+	if !target.Labels.IsEmpty() && !hasAnyLabel(i, target.Labels) {
+		return false, nil
+	}
+	if !target.Authors.IsEmpty() && !isAnyOf(i.GetUser().GetLogin(), target.Authors) {
+		return false, nil
+	}
+	if target.Title != "" {
+		re, err := regexp.Compile(target.Title)
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression `%s` for project target. error message : %s",
+				target.Title, err.Error())
+		}
+		return re.MatchString(i.GetTitle()), nil
+	}
+	return true, nil
+}
+
+func hasAnyLabel(i *gh.Issue, labels []string) bool {
+	for _, label := range i.Labels {
+		for _, name := range labels {
+			if strings.EqualFold(label.GetName(), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isAnyOf returns true if the given login is one of the given users, with or without the `@` prefix
+func isAnyOf(login string, users []string) bool {
+	for _, user := range users {
+		if strings.EqualFold(strings.TrimPrefix(user, "@"), login) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package assigner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+const infraIssuePayload = `{
+  "action": "opened",
+  "issue": {
+    "number": 1347,
+    "title": "Found a bug",
+    "labels": [{"name": "area:infra"}],
+    "user": {"login": "octocat"}
+  }
+}`
+
+var (
+	infraProject  = config.ProjectConfig{Owner: "octo-org", Number: 5, Column: "Todo"}
+	docsProject   = config.ProjectConfig{Owner: "octo-org", Number: 6}
+	triageProject = config.ProjectConfig{ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1", Column: "To Do"}
+)
+
+func projectTargets() []config.ProjectTarget {
+	return []config.ProjectTarget{
+		{ProjectConfig: infraProject, Labels: []string{"area:infra", "area:ci"}},
+		{ProjectConfig: docsProject, Authors: []string{"@monalisa"}, Title: `(?i)^docs`},
+		{ProjectConfig: triageProject},
+	}
+}
+
+func TestAssigner_issueProject(t *testing.T) {
+	tests := []struct {
+		name            string
+		targets         []config.ProjectTarget
+		issue           *gh.Issue
+		wantErr         bool
+		expectedError   error
+		expectedProject config.ProjectConfig
+	}{
+		{
+			name:            "should return the configured project if there are no targets",
+			issue:           &gh.Issue{},
+			expectedProject: infraProject,
+		},
+		{
+			name:    "should return the project of the first target with any of the issue labels",
+			targets: projectTargets(),
+			issue: &gh.Issue{
+				Title:  gh.String("Docs are outdated"),
+				User:   &gh.User{Login: gh.String("monalisa")},
+				Labels: []gh.Label{{Name: gh.String("bug")}, {Name: gh.String("Area:CI")}},
+			},
+			expectedProject: infraProject,
+		},
+		{
+			name:    "should return the project of the target whose conditions all match",
+			targets: projectTargets(),
+			issue: &gh.Issue{
+				Title: gh.String("Docs are outdated"),
+				User:  &gh.User{Login: gh.String("monalisa")},
+			},
+			expectedProject: docsProject,
+		},
+		{
+			name:    "should return the project of the target without conditions if no other target matches",
+			targets: projectTargets(),
+			issue: &gh.Issue{
+				Title: gh.String("Docs are outdated"),
+				User:  &gh.User{Login: gh.String("octocat")},
+			},
+			expectedProject: triageProject,
+		},
+		{
+			name:    "should return no project if no target matches",
+			targets: projectTargets()[:2],
+			issue:   &gh.Issue{Title: gh.String("Found a bug")},
+		},
+		{
+			name:          "should error if the title regular expression is invalid",
+			targets:       []config.ProjectTarget{{ProjectConfig: infraProject, Title: "(docs"}},
+			issue:         &gh.Issue{Title: gh.String("Found a bug")},
+			wantErr:       true,
+			expectedError: errors.New("invalid regular expression `(docs` for project target. error message : error parsing regexp: missing closing ): `(docs`"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					IssuesAssignerConfig: config.IssuesAssignerConfig{
+						IssuesAssignerProjectConfig: config.IssuesAssignerProjectConfig{
+							ProjectConfig: infraProject,
+							Targets:       tt.targets,
+						},
+					},
+				},
+			}
+			project, err := assigner.issueProject(tt.issue)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			if !reflect.DeepEqual(project, tt.expectedProject) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedProject, project)
+			}
+		})
+	}
+}
+
+func TestAssigner_ProjectTargets(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventName string
+		payload   string
+		responses []github.MockResponse
+	}{
+		{
+			name:      "should add the issue to the project of the matching target",
+			eventName: "issues",
+			payload:   infraIssuePayload,
+			responses: []github.MockResponse{
+				github.MockGetProjectV2Response(),
+				github.MockGetIssueResponse(),
+				github.MockAddProjectV2ItemResponse(),
+				github.MockUpdateProjectV2ItemFieldResponse(),
+			},
+		},
+		{
+			name:      "should move the issues that a new pull request resolves in the project of their target",
+			eventName: "pull_request",
+			payload:   linkedPullRequestPayload,
+			responses: []github.MockResponse{
+				github.MockGetIssueResponse(),
+				github.MockGetIssueResponse(),
+				github.MockListRepositoryProjectsResponse(),
+				github.MockListProjectColumnsResponse(),
+				github.MockListProjectCardsResponse(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := Assigner{
+				AssignerConfig: &config.AssignerConfig{
+					IssuesAssignerConfig: config.IssuesAssignerConfig{
+						IssuesAssignerProjectConfig: config.IssuesAssignerProjectConfig{
+							Columns: map[string]string{linkedPullRequestColumn: "To Do"},
+							Targets: projectTargets(),
+						},
+						Actions: []string{"opened"},
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := assigner.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, false, nil, err)
+		})
+	}
+}
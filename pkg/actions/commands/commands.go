@@ -0,0 +1,228 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const (
+	defaultPermission     = "write"
+	defaultPriorityPrefix = "priority:"
+
+	labelCommand    = "label"
+	unlabelCommand  = "unlabel"
+	assignCommand   = "assign"
+	priorityCommand = "priority"
+)
+
+// permissionLevels ranks the repository permissions from the lowest to the highest
+var permissionLevels = map[string]int{
+	"none":     0,
+	"read":     1,
+	"triage":   2,
+	"write":    3,
+	"maintain": 4,
+	"admin":    5,
+}
+
+// command is the struct to represent a slash command of a comment, e.g. `/label bug`
+type command struct {
+	Name string
+	Args []string
+}
+
+// Dispatcher is the struct to handle the slash commands of issue and pull request comments
+type Dispatcher struct {
+	*config.CommandsConfig
+	github.Repo
+}
+
+// HandleEvent runs the enabled slash commands of a newly created comment if the commenter has the required
+// permission. It reacts to the comment with `+1` if all the commands succeed and replies with the reason otherwise
+func (d *Dispatcher) HandleEvent(eventName string, payload *[]byte) error {
+	if d.Enabled.IsEmpty() {
+		return nil
+	}
+	event, err := gh.ParseWebHook(eventName, *payload)
+	if err != nil {
+		return err
+	}
+	commentEvent, ok := event.(*gh.IssueCommentEvent)
+	if !ok || commentEvent.GetAction() != "created" {
+		return nil
+	}
+	comment := commentEvent.GetComment()
+	if comment.GetUser().GetType() == "Bot" {
+		return nil
+	}
+
+	commands := parseCommands(comment.GetBody())
+	var enabled []command
+	for _, c := range commands {
+		if d.Enabled.HasString(c.Name) {
+			enabled = append(enabled, c)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	issue := github.NewIssue(d.Repo, commentEvent.GetIssue().GetNumber())
+	commenter := comment.GetUser().GetLogin()
+	allowed, err := d.isAllowed(commenter)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return issue.AddComment(fmt.Sprintf("@%s you need `%s` permission on this repository to run commands",
+			commenter, d.permission()))
+	}
+
+	merr := new(multierror.Error)
+	for _, c := range enabled {
+		merr = multierror.Append(merr, d.run(issue, commenter, c))
+	}
+	if err := merr.ErrorOrNil(); err != nil {
+		return multierror.Append(err,
+			issue.AddReaction(comment.GetID(), "confused"),
+			issue.AddComment(fmt.Sprintf("@%s some commands failed:\n\n```\n%s\n```", commenter, err.Error())),
+		).ErrorOrNil()
+	}
+	return issue.AddReaction(comment.GetID(), "+1")
+}
+
+// parseCommands returns the commands of the given comment body. Each command is a line starting with a slash
+// followed by the command name and its arguments
+func parseCommands(body string) []command {
+	var commands []command
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") {
+			continue
+		}
+		fields := strings.SplitN(line[1:], " ", 2)
+		c := command{Name: strings.ToLower(fields[0])}
+		if len(fields) > 1 {
+			c.Args = splitArgs(c.Name, fields[1])
+		}
+		commands = append(commands, c)
+	}
+	return commands
+}
+
+// splitArgs splits the arguments of a command. Labels are separated by commas, so that they may contain spaces, while
+// users are separated by commas and/or whitespace
+func splitArgs(name, args string) []string {
+	separator := func(r rune) bool { return r == ',' }
+	if name == assignCommand {
+		separator = func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }
+	}
+	var result []string
+	for _, arg := range strings.FieldsFunc(args, separator) {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			result = append(result, arg)
+		}
+	}
+	return result
+}
+
+func (d *Dispatcher) permission() string {
+	if d.Permission == "" {
+		return defaultPermission
+	}
+	return strings.ToLower(d.Permission)
+}
+
+// isAllowed returns true if the given user has at least the configured permission on the repository. It errors if
+// either permission is unknown so that a misconfigured permission never lets every commenter run commands
+func (d *Dispatcher) isAllowed(user string) (bool, error) {
+	required, ok := permissionLevels[d.permission()]
+	if !ok {
+		return false, fmt.Errorf("cannot check the permission of %s. error message : unknown permission %s", user, d.Permission)
+	}
+	permission, err := d.Repo.PermissionLevel(user)
+	if err != nil {
+		return false, err
+	}
+	level, ok := permissionLevels[permission]
+	if !ok {
+		return false, fmt.Errorf("cannot check the permission of %s. error message : unknown permission %s", user, permission)
+	}
+	return level >= required, nil
+}
+
+// run runs the given command on the given issue/pull request
+func (d *Dispatcher) run(issue github.Issue, commenter string, c command) error {
+	log.Printf("Running command /%s %s on %s/%s#%d", c.Name, c.Args, issue.Owner, issue.Name, issue.Number)
+	switch c.Name {
+	case labelCommand:
+		if len(c.Args) == 0 {
+			return fmt.Errorf("/%s needs at least one label", c.Name)
+		}
+		currLabels, err := issue.CurrentLabels()
+		if err != nil {
+			return err
+		}
+		for _, label := range c.Args {
+			if !currLabels.HasString(label) {
+				currLabels = currLabels.Add(label)
+			}
+		}
+		return issue.ReplaceLabels(currLabels)
+	case unlabelCommand:
+		if len(c.Args) == 0 {
+			return fmt.Errorf("/%s needs at least one label", c.Name)
+		}
+		return issue.RemoveLabels(c.Args...)
+	case assignCommand:
+		users := []string{commenter}
+		if len(c.Args) > 0 {
+			users = make([]string, 0, len(c.Args))
+			for _, arg := range c.Args {
+				users = append(users, strings.TrimPrefix(arg, "@"))
+			}
+		}
+		return issue.AddAssignees(users...)
+	case priorityCommand:
+		if len(c.Args) != 1 {
+			return fmt.Errorf("/%s needs exactly one priority", c.Name)
+		}
+		return d.setPriority(issue, c.Args[0])
+	}
+	return nil
+}
+
+// setPriority replaces any priority label of the given issue/pull request with the label of the given priority
+func (d *Dispatcher) setPriority(issue github.Issue, priority string) error {
+	prefix := d.PriorityPrefix
+	if prefix == "" {
+		prefix = defaultPriorityPrefix
+	}
+	currLabels, err := issue.CurrentLabels()
+	if err != nil {
+		return err
+	}
+	var desiredLabels slices.StringSlice
+	for _, label := range currLabels {
+		if !strings.HasPrefix(label, prefix) {
+			desiredLabels = desiredLabels.Add(label)
+		}
+	}
+	return issue.ReplaceLabels(desiredLabels.Add(prefix + priority))
+}
+
+// New creates a new slash command dispatcher object
+func New(c *config.Config, repo github.Repo) *Dispatcher {
+	return &Dispatcher{
+		CommandsConfig: &c.CommandsConfig,
+		Repo:           repo,
+	}
+}
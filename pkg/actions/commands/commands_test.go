@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func commentPayload(action, body, userType string) string {
+	return fmt.Sprintf(`{
+  "action": "%s",
+  "issue": {
+    "number": 1347,
+    "title": "Found a bug"
+  },
+  "comment": {
+    "id": 2,
+    "body": %q,
+    "user": {
+      "login": "octocat",
+      "type": "%s"
+    }
+  }
+}`, action, body, userType)
+}
+
+func TestParseCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []command
+	}{
+		{
+			name: "should return no commands if there are no slash lines",
+			body: "Looks good to me\nthanks /label bug",
+		},
+		{
+			name: "should parse the commands of all lines",
+			body: "Thanks!\n/label bug, good first issue\r\n  /Unlabel wontfix\n/assign @octocat hubot,@monalisa\n/priority 1\n/assign",
+			expected: []command{
+				{Name: "label", Args: []string{"bug", "good first issue"}},
+				{Name: "unlabel", Args: []string{"wontfix"}},
+				{Name: "assign", Args: []string{"@octocat", "hubot", "@monalisa"}},
+				{Name: "priority", Args: []string{"1"}},
+				{Name: "assign"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := parseCommands(tt.body)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDispatcher_HandleEvent(t *testing.T) {
+	allCommands := []string{"label", "unlabel", "assign", "priority"}
+	tests := []struct {
+		name          string
+		eventName     string
+		payload       string
+		config        config.CommandsConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should do nothing if no commands are enabled",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/label bug", "User"),
+		},
+		{
+			name:      "should do nothing if the comment is edited",
+			eventName: "issue_comment",
+			payload:   commentPayload("edited", "/label bug", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands},
+		},
+		{
+			name:      "should do nothing if the comment is created by a bot",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/label bug", "Bot"),
+			config:    config.CommandsConfig{Enabled: allCommands},
+		},
+		{
+			name:      "should do nothing if the comment has no enabled commands",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/label bug\n/close", "User"),
+			config:    config.CommandsConfig{Enabled: []string{"assign"}},
+		},
+		{
+			name:      "should reply if the commenter doesn't have the required permission",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/label bug", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands, Permission: "maintain"},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("write", "write"),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should run the commands of maintainers if the maintain permission is required",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/assign", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands, Permission: "maintain"},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("write", "maintain"),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should run the commands of triagers if the triage permission is required",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/assign", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands, Permission: "triage"},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("read", "triage"),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should ignore the case of the required permission",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/assign", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands, Permission: "Write"},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("write", "write"),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:          "should error if the required permission is unknown",
+			eventName:     "issue_comment",
+			payload:       commentPayload("created", "/label bug", "User"),
+			config:        config.CommandsConfig{Enabled: allCommands, Permission: "maintainer"},
+			wantErr:       true,
+			expectedError: errors.New("cannot check the permission of octocat. error message : unknown permission maintainer"),
+		},
+		{
+			name:      "should error if the permission of the commenter is unknown",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/label bug", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("superuser", ""),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot check the permission of octocat. error message : unknown permission superuser"),
+		},
+		{
+			name:      "should run the commands and react to the comment",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/label triage, good first issue\n/unlabel bug\n/assign\n/priority 1", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("admin", "admin"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should react and reply if a command fails",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/priority", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands},
+			responses: []github.MockResponse{
+				github.MockGetPermissionLevelResponse("write", "write"),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("1 error occurred:\n\t* /priority needs exactly one priority\n\n"),
+		},
+		{
+			name:      "should error if the permission of the commenter cannot be retrieved",
+			eventName: "issue_comment",
+			payload:   commentPayload("created", "/assign", "User"),
+			config:    config.CommandsConfig{Enabled: allCommands},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get the permission of octocat on ppapapetrou76/virtual-assistant. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/collaborators/octocat/permission: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := Dispatcher{
+				CommandsConfig: &tt.config,
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := dispatcher.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package conflict
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v27/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const (
+	defaultRetries = 5
+	defaultDelay   = time.Second
+	// defaultMaxWait caps the total time spent waiting for GitHub to compute the mergeable states of an event's pull
+	// requests
+	defaultMaxWait = 30 * time.Second
+	// commentMarker is a hidden marker added to the conflict comments so that they can be found and deleted once the
+	// conflict is fixed
+	commentMarker = "<!-- virtual-assistant:merge-conflict -->"
+)
+
+// eligibleActions are the pull request event actions that can introduce or fix a conflict
+var eligibleActions = slices.StringSlice{"opened", "reopened", "synchronize"}
+
+// Detector is the struct to handle the labeling of pull requests with merge conflicts
+type Detector struct {
+	*config.MergeConflictConfig
+	github.Repo
+	// delay is the initial delay between the checks of the mergeable state of a pull request
+	delay time.Duration
+	// maxWait is the maximum total time spent waiting for the mergeable states to be computed
+	maxWait time.Duration
+}
+
+// HandleEvent checks the mergeable state of the open pull requests that target the pushed branch, or of the pull
+// request of a pull request event, and adds or removes the conflict label and comment accordingly
+func (d *Detector) HandleEvent(eventName string, payload *[]byte) error {
+	if d.Label == "" {
+		return nil
+	}
+	event, err := gh.ParseWebHook(eventName, *payload)
+	if err != nil {
+		return err
+	}
+	switch event := event.(type) {
+	case *gh.PushEvent:
+		if !strings.HasPrefix(event.GetRef(), "refs/heads/") {
+			return nil
+		}
+		pullRequests, err := d.Repo.OpenPullRequests(strings.TrimPrefix(event.GetRef(), "refs/heads/"))
+		if err != nil {
+			return err
+		}
+		numbers := make([]int, 0, len(pullRequests))
+		for _, pr := range pullRequests {
+			numbers = append(numbers, pr.GetNumber())
+		}
+		return d.check(numbers...)
+	case *gh.PullRequestEvent:
+		if !eligibleActions.HasString(event.GetAction()) {
+			log.Printf("Pull request event is `%s` - eligible actions are `%v`. Skipping merge conflict detection",
+				event.GetAction(), eligibleActions)
+			return nil
+		}
+		return d.check(event.PullRequest.GetNumber())
+	}
+	return nil
+}
+
+// check adds the conflict label and comment to the given pull requests that have conflicts and removes them from the
+// rest. Nothing changes for the pull requests whose mergeable state is still unknown
+func (d *Detector) check(numbers ...int) error {
+	merr := new(multierror.Error)
+	states, err := d.mergeableStates(numbers)
+	merr = multierror.Append(merr, err)
+	for _, number := range numbers {
+		mergeable, ok := states[number]
+		if !ok {
+			continue
+		}
+		if mergeable == nil {
+			log.Printf("Mergeable state of pull request #%d is unknown. Skipping merge conflict detection", number)
+			continue
+		}
+		merr = multierror.Append(merr, d.update(github.NewIssue(d.Repo, number), *mergeable))
+	}
+	return merr.ErrorOrNil()
+}
+
+// mergeableStates returns the mergeable state of the given pull requests keyed by their number. GitHub computes it
+// lazily in a background job so all of them are requested first and only the ones that are still unknown are checked
+// again, doubling the delay between the retries, until the retries run out or the total wait would exceed maxWait.
+// The pull requests that cannot be retrieved are left out
+func (d *Detector) mergeableStates(numbers []int) (map[int]*bool, error) {
+	retries := d.Retries
+	if retries == 0 {
+		retries = defaultRetries
+	}
+	merr := new(multierror.Error)
+	states := map[int]*bool{}
+	pending := numbers
+	delay, waited := d.delay, time.Duration(0)
+	for attempt := 0; ; attempt++ {
+		var unknown []int
+		for _, number := range pending {
+			mergeable, err := github.NewIssue(d.Repo, number).Mergeable()
+			if err != nil {
+				merr = multierror.Append(merr, err)
+				continue
+			}
+			states[number] = mergeable
+			if mergeable == nil {
+				unknown = append(unknown, number)
+			}
+		}
+		if len(unknown) == 0 || attempt >= retries || waited+delay > d.maxWait {
+			return states, merr.ErrorOrNil()
+		}
+		log.Printf("Mergeable state of pull requests %v is not computed yet. Checking again in %s", unknown, delay)
+		time.Sleep(delay)
+		waited += delay
+		delay *= 2
+		pending = unknown
+	}
+}
+
+// update adds the conflict label and comment to the given pull request if it's not mergeable and removes them otherwise
+func (d *Detector) update(pullRequest github.Issue, mergeable bool) error {
+	currLabels, err := pullRequest.CurrentLabels()
+	if err != nil {
+		return err
+	}
+	hasLabel := currLabels.HasString(d.Label)
+
+	switch {
+	case !mergeable && !hasLabel:
+		if err := pullRequest.ReplaceLabels(currLabels.Add(d.Label)); err != nil {
+			return err
+		}
+		if d.Comment != "" {
+			return pullRequest.AddComment(d.Comment + "\n\n" + commentMarker)
+		}
+	case mergeable && hasLabel:
+		if err := pullRequest.ReplaceLabels(currLabels.Remove(d.Label)); err != nil {
+			return err
+		}
+		return d.deleteComments(pullRequest)
+	}
+	return nil
+}
+
+// deleteComments deletes the conflict comments of the given pull request
+func (d *Detector) deleteComments(pullRequest github.Issue) error {
+	if d.Comment == "" {
+		return nil
+	}
+	comments, err := pullRequest.Comments()
+	if err != nil {
+		return err
+	}
+	merr := new(multierror.Error)
+	for _, comment := range comments {
+		if strings.Contains(comment.GetBody(), commentMarker) {
+			merr = multierror.Append(merr, pullRequest.DeleteComment(comment.GetID()))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// New creates a new merge conflict detector object
+func New(c *config.Config, repo github.Repo) *Detector {
+	return &Detector{
+		MergeConflictConfig: &c.MergeConflictConfig,
+		Repo:                repo,
+		delay:               defaultDelay,
+		maxWait:             defaultMaxWait,
+	}
+}
@@ -0,0 +1,199 @@
+package conflict
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+const webhookPushPayload = `{
+  "ref": "refs/heads/master",
+  "before": "6113728f27ae82c7b1a177c8d03f9e96e0adf246",
+  "after": "0000000000000000000000000000000000000000"
+}`
+
+const webhookTagPushPayload = `{
+  "ref": "refs/tags/v1.0.0"
+}`
+
+const webhookSynchronizePayload = `{
+  "action": "synchronize",
+  "number": 1347,
+  "pull_request": {
+    "id": 1,
+    "number": 1347,
+    "state": "open"
+  }
+}`
+
+const webhookClosedPayload = `{
+  "action": "closed",
+  "number": 1347,
+  "pull_request": {
+    "id": 1,
+    "number": 1347,
+    "state": "closed"
+  }
+}`
+
+func TestDetector_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventName     string
+		payload       string
+		config        config.MergeConflictConfig
+		delay         time.Duration
+		maxWait       time.Duration
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should do nothing if no label is configured",
+			eventName: "pull_request",
+			payload:   webhookSynchronizePayload,
+		},
+		{
+			name:      "should do nothing if the pull request action is not eligible",
+			eventName: "pull_request",
+			payload:   webhookClosedPayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase"},
+		},
+		{
+			name:      "should do nothing if a tag is pushed",
+			eventName: "push",
+			payload:   webhookTagPushPayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase"},
+		},
+		{
+			name:      "should add the label and the comment if the pull request has conflicts",
+			eventName: "pull_request",
+			payload:   webhookSynchronizePayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase", Comment: "This pull request has conflicts"},
+			responses: []github.MockResponse{
+				github.MockGetPullRequestResponse("false"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should not add the label again if the pull request is already labeled",
+			eventName: "pull_request",
+			payload:   webhookSynchronizePayload,
+			config:    config.MergeConflictConfig{Label: "bug", Comment: "This pull request has conflicts"},
+			responses: []github.MockResponse{
+				github.MockGetPullRequestResponse("false"),
+				github.MockListIssueLabelsResponse(),
+			},
+		},
+		{
+			name:      "should remove the label and the comment once the conflict is fixed",
+			eventName: "pull_request",
+			payload:   webhookSynchronizePayload,
+			config:    config.MergeConflictConfig{Label: "bug", Comment: "This pull request has conflicts"},
+			responses: []github.MockResponse{
+				github.MockGetPullRequestResponse("true"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockListIssueCommentsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should do nothing if the mergeable state is unknown",
+			eventName: "pull_request",
+			payload:   webhookSynchronizePayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase", Retries: 1},
+			responses: []github.MockResponse{
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("null"),
+			},
+		},
+		{
+			name:      "should check all the open pull requests of the pushed branch",
+			eventName: "push",
+			payload:   webhookPushPayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase"},
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockGetPullRequestResponse("true"),
+				github.MockGetPullRequestResponse("false"),
+				github.MockGetPullRequestResponse("true"),
+				github.MockListIssueLabelsResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockListIssueLabelsResponse(),
+			},
+		},
+		{
+			name:      "should check again only the pull requests whose mergeable state is unknown",
+			eventName: "push",
+			payload:   webhookPushPayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase"},
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("true"),
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("false"),
+				github.MockGetPullRequestResponse("true"),
+				github.MockListIssueLabelsResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should stop checking the unknown mergeable states once the maximum wait is reached",
+			eventName: "push",
+			payload:   webhookPushPayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase"},
+			delay:     10 * time.Millisecond,
+			maxWait:   25 * time.Millisecond,
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("false"),
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("null"),
+				github.MockGetPullRequestResponse("null"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should error if the open pull requests cannot be retrieved",
+			eventName: "push",
+			payload:   webhookPushPayload,
+			config:    config.MergeConflictConfig{Label: "needs-rebase"},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get open pull requests of branch master. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls?base=master&per_page=100&state=open: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := Detector{
+				MergeConflictConfig: &tt.config,
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+				delay:   tt.delay,
+				maxWait: tt.maxWait,
+			}
+			payload := []byte(tt.payload)
+			err := detector.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package labeler
+
+import (
+	"encoding/json"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// issueAuthorAssociation returns the author association of the issue of an issues event payload. The go-github
+// issue struct doesn't expose it so it's read directly from the raw payload
+func issueAuthorAssociation(payload *[]byte) string {
+	var event struct {
+		Issue struct {
+			AuthorAssociation string `json:"author_association"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(*payload, &event); err != nil {
+		return ""
+	}
+	return event.Issue.AuthorAssociation
+}
+
+// authorLabels returns the labels of the author rules that apply to the given target and match the given author
+func (l *Labeler) authorLabels(target string, author *gh.User, association string) (slices.StringSlice, error) {
+	var labels slices.StringSlice
+	for _, rule := range l.AuthorLabels {
+		if !rule.AppliesTo(target) || labels.HasString(rule.Label) {
+			continue
+		}
+		matched, err := l.authorMatches(rule, author, association)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			labels = labels.Add(rule.Label)
+		}
+	}
+	return labels, nil
+}
+
+func (l *Labeler) authorMatches(rule config.AuthorLabel, author *gh.User, association string) (bool, error) {
+	if !rule.Associations.IsEmpty() && !rule.Associations.HasString(association) {
+		return false, nil
+	}
+	if rule.Bot && !isBot(author) {
+		return false, nil
+	}
+	if rule.MemberOf != "" {
+		isMember, err := l.Repo.IsOrgMember(rule.MemberOf, author.GetLogin())
+		if err != nil || !isMember {
+			return false, err
+		}
+	}
+	if rule.NotMemberOf != "" {
+		isMember, err := l.Repo.IsOrgMember(rule.NotMemberOf, author.GetLogin())
+		if err != nil || isMember {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func isBot(user *gh.User) bool {
+	return user.GetType() == "Bot" || strings.HasSuffix(user.GetLogin(), "[bot]")
+}
@@ -0,0 +1,119 @@
+package labeler
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func TestIssueAuthorAssociation(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  []byte
+		expected string
+	}{
+		{
+			name:     "should return the author association of the issue",
+			payload:  []byte(`{"action": "opened", "issue": {"number": 1, "author_association": "FIRST_TIME_CONTRIBUTOR"}}`),
+			expected: "FIRST_TIME_CONTRIBUTOR",
+		},
+		{
+			name:    "should return an empty association if the payload is invalid",
+			payload: []byte("random payload"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := issueAuthorAssociation(&tt.payload)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLabeler_authorLabels(t *testing.T) {
+	rules := []config.AuthorLabel{
+		{Label: "community", Associations: []string{"FIRST_TIME_CONTRIBUTOR", "CONTRIBUTOR"}},
+		{Label: "dependencies", Bot: true, Target: config.PullRequestsTarget},
+		{Label: "internal", MemberOf: "myorg", Associations: []string{"MEMBER"}},
+		{Label: "external", NotMemberOf: "myorg", Associations: []string{"NONE"}},
+	}
+	notFound := github.MockResponse{StatusCode: http.StatusNotFound, Response: `{"message": "Not Found"}`}
+	isMember := github.MockResponse{StatusCode: http.StatusNoContent}
+
+	type args struct {
+		target      string
+		login       string
+		userType    string
+		association string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		responses      []github.MockResponse
+		wantErr        bool
+		expectedError  error
+		expectedLabels slices.StringSlice
+	}{
+		{
+			name:           "should add the labels of the matching associations",
+			args:           args{target: config.IssuesTarget, login: "octocat", association: "FIRST_TIME_CONTRIBUTOR"},
+			expectedLabels: []string{"community"},
+		},
+		{
+			name:           "should add the labels of bot authors",
+			args:           args{target: config.PullRequestsTarget, login: "dependabot[bot]", userType: "Bot", association: "NONE"},
+			responses:      []github.MockResponse{notFound},
+			expectedLabels: []string{"dependencies", "external"},
+		},
+		{
+			name: "should skip rules of other targets",
+			args: args{target: config.IssuesTarget, login: "dependabot[bot]", userType: "Bot", association: "NONE"},
+			responses: []github.MockResponse{
+				isMember,
+			},
+		},
+		{
+			name:           "should add the labels of organization members",
+			args:           args{target: config.IssuesTarget, login: "octocat", association: "MEMBER"},
+			responses:      []github.MockResponse{isMember},
+			expectedLabels: []string{"internal"},
+		},
+		{
+			name:          "should error if the membership cannot be checked",
+			args:          args{target: config.IssuesTarget, login: "octocat", association: "MEMBER"},
+			responses:     []github.MockResponse{github.UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot check if octocat is a member of myorg. error message : GET https://api.github.com/orgs/myorg/members/octocat: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					AuthorLabels: rules,
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			author := &gh.User{Login: &tt.args.login, Type: &tt.args.userType}
+			actual, err := labeler.authorLabels(tt.args.target, author, tt.args.association)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package labeler
+
+import (
+	"fmt"
+	"regexp"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/glob"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// branchLabels returns the labels of the rules that match the head and base branches of the given pull request
+func branchLabels(rules []config.BranchLabel, pr *gh.PullRequest) (slices.StringSlice, error) {
+	var labels slices.StringSlice
+	for _, rule := range rules {
+		headMatches, err := branchMatches(rule, rule.Head, pr.GetHead().GetRef())
+		if err != nil {
+			return nil, err
+		}
+		baseMatches, err := branchMatches(rule, rule.Base, pr.GetBase().GetRef())
+		if err != nil {
+			return nil, err
+		}
+		if headMatches && baseMatches && !labels.HasString(rule.Label) {
+			labels = labels.Add(rule.Label)
+		}
+	}
+	return labels, nil
+}
+
+// branchMatches returns true if the branch matches the pattern or if there's no pattern at all
+func branchMatches(rule config.BranchLabel, pattern, branch string) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+	if !rule.Regex {
+		return glob.Match(pattern, branch), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression `%s` for label %s. error message : %s",
+			pattern, rule.Label, err.Error())
+	}
+	return re.MatchString(branch), nil
+}
@@ -0,0 +1,75 @@
+package labeler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func TestBranchLabels(t *testing.T) {
+	rules := []config.BranchLabel{
+		{Label: "backport", Base: "release/*"},
+		{Label: "bug", Head: "fix/**"},
+		{Label: "hotfix", Head: `^hotfix-\d+$`, Base: "^main$", Regex: true},
+	}
+	type args struct {
+		rules []config.BranchLabel
+		head  string
+		base  string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantErr        bool
+		expectedError  error
+		expectedLabels slices.StringSlice
+	}{
+		{
+			name:           "should add the labels of the matching base branch",
+			args:           args{rules: rules, head: "feature/login", base: "release/1.4"},
+			expectedLabels: []string{"backport"},
+		},
+		{
+			name:           "should add the labels of the matching head branch",
+			args:           args{rules: rules, head: "fix/parser/npe", base: "release/1.4"},
+			expectedLabels: []string{"backport", "bug"},
+		},
+		{
+			name:           "should add the labels of rules matching both branches",
+			args:           args{rules: rules, head: "hotfix-123", base: "main"},
+			expectedLabels: []string{"hotfix"},
+		},
+		{
+			name: "should not add the labels of rules matching only one of the branches",
+			args: args{rules: rules, head: "hotfix-123", base: "develop"},
+		},
+		{
+			name: "should error if a regular expression is invalid",
+			args: args{
+				rules: []config.BranchLabel{{Label: "hotfix", Head: "hotfix-(", Regex: true}},
+				head:  "hotfix-1",
+			},
+			wantErr:       true,
+			expectedError: errors.New("invalid regular expression `hotfix-(` for label hotfix. error message : error parsing regexp: missing closing ): `hotfix-(`"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &gh.PullRequest{
+				Head: &gh.PullRequestBranch{Ref: &tt.args.head},
+				Base: &gh.PullRequestBranch{Ref: &tt.args.base},
+			}
+			actual, err := branchLabels(tt.args.rules, pr)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
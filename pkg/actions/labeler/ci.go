@@ -0,0 +1,142 @@
+package labeler
+
+import (
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+)
+
+const (
+	ciPassing = "passing"
+	ciFailing = "failing"
+	ciPending = "pending"
+)
+
+// checkState returns the CI state of a check run or a check suite. Checks that are not completed are pending and
+// completed ones pass unless their conclusion is a failure, an error, a cancellation etc.
+func checkState(status, conclusion string) string {
+	if status != "completed" {
+		return ciPending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return ciPassing
+	}
+	return ciFailing
+}
+
+// statusState returns the CI state of a commit status
+func statusState(state string) string {
+	switch state {
+	case "success":
+		return ciPassing
+	case "pending":
+		return ciPending
+	}
+	return ciFailing
+}
+
+// ciLabel returns the label of the given CI state
+func ciLabel(labels config.CIStateLabels, state string) string {
+	switch state {
+	case ciPassing:
+		return labels.Passing
+	case ciPending:
+		return labels.Pending
+	}
+	return labels.Failing
+}
+
+// combineStates returns the state of many checks: failing if any of them fails, otherwise pending if any of them is
+// pending, otherwise passing
+func combineStates(states []string) string {
+	combined := ciPassing
+	for _, state := range states {
+		switch state {
+		case ciFailing:
+			return ciFailing
+		case ciPending:
+			combined = ciPending
+		}
+	}
+	return combined
+}
+
+// combinedState returns the combined state of all the check runs and statuses of the given commit that are not mapped
+// to their own labels
+func (l *Labeler) combinedState(sha string) (string, error) {
+	ci := l.PullRequestsLabelerConfig.CI
+	checkRuns, err := l.Repo.CheckRuns(sha)
+	if err != nil {
+		return "", err
+	}
+	statuses, err := l.Repo.Statuses(sha)
+	if err != nil {
+		return "", err
+	}
+
+	var states []string
+	for _, run := range checkRuns {
+		if !ci.IsMapped(run.GetName()) {
+			states = append(states, checkState(run.GetStatus(), run.GetConclusion()))
+		}
+	}
+	for _, status := range statuses {
+		if !ci.IsMapped(status.GetContext()) {
+			states = append(states, statusState(status.GetState()))
+		}
+	}
+	return combineStates(states), nil
+}
+
+// updateCILabels replaces the CI label of the given check on all the open pull requests whose head is the given commit.
+// Checks mapped to their own labels get the label of the given state while the shared labels get the combined state
+// of all the other checks of the commit, so that a passing check doesn't hide a failing one. An empty check stands
+// for a check suite, which only updates the shared labels as all the workflows of an app share its name
+func (l *Labeler) updateCILabels(sha, check, state string) error {
+	ci := l.PullRequestsLabelerConfig.CI
+	shared := check == "" || !ci.IsMapped(check)
+	labels := ci.CIStateLabels
+	if !shared {
+		labels = ci.Checks[check]
+	}
+	if !labels.IsEnabled() {
+		return nil
+	}
+
+	pullRequests, err := l.Repo.PullRequestsWithCommit(sha)
+	if err != nil {
+		return err
+	}
+
+	merr := new(multierror.Error)
+	for _, pr := range pullRequests {
+		// statuses of older commits of the pull request are not relevant anymore
+		if pr.GetState() != "open" || pr.GetHead().GetSHA() != sha {
+			continue
+		}
+		if shared {
+			// the combined state is the same for all the pull requests of the commit so it's computed once
+			if state, err = l.combinedState(sha); err != nil {
+				return err
+			}
+			shared = false
+		}
+		log.Printf("CI of pull request #%d is %s after check %s", pr.GetNumber(), state, check)
+		pullRequest := github.NewIssue(l.Repo, pr.GetNumber())
+		currLabels, err := pullRequest.CurrentLabels()
+		if err != nil {
+			merr = multierror.Append(merr, err)
+			continue
+		}
+		desiredLabels := currLabels.Remove(labels.Passing, labels.Failing, labels.Pending)
+		if label := ciLabel(labels, state); label != "" {
+			desiredLabels = desiredLabels.Add(label)
+		}
+		merr = multierror.Append(merr, pullRequest.ReplaceLabels(desiredLabels))
+	}
+	return merr.ErrorOrNil()
+}
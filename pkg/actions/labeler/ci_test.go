@@ -0,0 +1,300 @@
+package labeler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+const webhookCheckRunPayload = `{
+  "action": "completed",
+  "check_run": {
+    "id": 128620228,
+    "head_sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+    "status": "completed",
+    "conclusion": "failure",
+    "name": "lint"
+  }
+}`
+
+const webhookCheckSuitePayload = `{
+  "action": "completed",
+  "check_suite": {
+    "id": 118578147,
+    "head_sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+    "status": "completed",
+    "conclusion": "success",
+    "app": {
+      "name": "GitHub Actions"
+    }
+  }
+}`
+
+const webhookStatusPayload = `{
+  "id": 6805126730,
+  "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+  "context": "ci/jenkins",
+  "state": "pending"
+}`
+
+func TestCheckState(t *testing.T) {
+	tests := []struct {
+		status, conclusion string
+		expected           string
+	}{
+		{status: "queued", expected: ciPending},
+		{status: "in_progress", expected: ciPending},
+		{status: "completed", conclusion: "success", expected: ciPassing},
+		{status: "completed", conclusion: "neutral", expected: ciPassing},
+		{status: "completed", conclusion: "skipped", expected: ciPassing},
+		{status: "completed", conclusion: "failure", expected: ciFailing},
+		{status: "completed", conclusion: "timed_out", expected: ciFailing},
+		{status: "completed", conclusion: "cancelled", expected: ciFailing},
+		{status: "completed", conclusion: "action_required", expected: ciFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status+"/"+tt.conclusion, func(t *testing.T) {
+			actual := checkState(tt.status, tt.conclusion)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStatusState(t *testing.T) {
+	tests := map[string]string{
+		"success": ciPassing,
+		"pending": ciPending,
+		"failure": ciFailing,
+		"error":   ciFailing,
+	}
+	for state, expected := range tests {
+		t.Run(state, func(t *testing.T) {
+			actual := statusState(state)
+			if actual != expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestLabeler_updateCILabels(t *testing.T) {
+	cfg := config.CILabels{
+		CIStateLabels: config.CIStateLabels{Passing: "ci:passing", Failing: "ci:failing", Pending: "ci:pending"},
+		Checks: map[string]config.CIStateLabels{
+			"lint": {Failing: "lint:failing"},
+		},
+	}
+	tests := []struct {
+		name          string
+		config        config.CILabels
+		eventName     string
+		payload       string
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should replace the label of the open pull requests of a check run",
+			config:    cfg,
+			eventName: "check_run",
+			payload:   webhookCheckRunPayload,
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should replace the label of the open pull requests of a check suite",
+			config:    cfg,
+			eventName: "check_suite",
+			payload:   webhookCheckSuitePayload,
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockListCheckRunsResponse("completed", "failure"),
+				github.MockGetCombinedStatusResponse("pending"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should replace the label of the open pull requests of a status",
+			config:    cfg,
+			eventName: "status",
+			payload:   webhookStatusPayload,
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockListCheckRunsResponse("completed", "failure"),
+				github.MockGetCombinedStatusResponse("pending"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should replace the shared label with the combined state of all the checks of the commit",
+			config:    config.CILabels{CIStateLabels: cfg.CIStateLabels},
+			eventName: "check_run",
+			payload:   webhookCheckRunPayload,
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.MockListCheckRunsResponse("completed", "failure"),
+				github.MockGetCombinedStatusResponse("success"),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should do nothing if the check has no labels",
+			config: config.CILabels{
+				Checks: map[string]config.CIStateLabels{"build": {Passing: "build:passing"}},
+			},
+			eventName: "check_run",
+			payload:   webhookCheckRunPayload,
+		},
+		{
+			name:      "should error if the check runs of the commit cannot be retrieved",
+			config:    cfg,
+			eventName: "status",
+			payload:   webhookStatusPayload,
+			responses: []github.MockResponse{
+				github.MockListPullRequestsWithCommitResponse(),
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get check runs of commit 6dcb09b5b57875f334f61aebed695e2e4193db5e. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/check-runs?per_page=100: 401 Bad credentials []"),
+		},
+		{
+			name:      "should error if the pull requests of the commit cannot be retrieved",
+			config:    cfg,
+			eventName: "status",
+			payload:   webhookStatusPayload,
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get pull requests of commit 6dcb09b5b57875f334f61aebed695e2e4193db5e. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/pulls?per_page=100: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						CI: tt.config,
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			payload := []byte(tt.payload)
+			err := labeler.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestCombineStates(t *testing.T) {
+	tests := []struct {
+		name     string
+		states   []string
+		expected string
+	}{
+		{name: "should pass without checks", expected: ciPassing},
+		{name: "should pass if all checks pass", states: []string{ciPassing, ciPassing}, expected: ciPassing},
+		{name: "should be pending if any check is pending", states: []string{ciPassing, ciPending}, expected: ciPending},
+		{name: "should fail if any check fails", states: []string{ciFailing, ciPending, ciPassing}, expected: ciFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := combineStates(tt.states); actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLabeler_combinedState(t *testing.T) {
+	tests := []struct {
+		name      string
+		checks    map[string]config.CIStateLabels
+		responses []github.MockResponse
+		expected  string
+	}{
+		{
+			name:   "should fail if a check fails even if a later check passes",
+			checks: map[string]config.CIStateLabels{"codecov": {}},
+			responses: []github.MockResponse{
+				github.MockListCheckRunsResponse("completed", "failure"),
+				github.MockGetCombinedStatusResponse("success"),
+			},
+			expected: ciFailing,
+		},
+		{
+			name:   "should be pending if a check is still running",
+			checks: map[string]config.CIStateLabels{"codecov": {}},
+			responses: []github.MockResponse{
+				github.MockListCheckRunsResponse("in_progress", ""),
+				github.MockGetCombinedStatusResponse("success"),
+			},
+			expected: ciPending,
+		},
+		{
+			name:   "should be pending if a status is pending",
+			checks: map[string]config.CIStateLabels{"codecov": {}},
+			responses: []github.MockResponse{
+				github.MockListCheckRunsResponse("completed", "success"),
+				github.MockGetCombinedStatusResponse("pending"),
+			},
+			expected: ciPending,
+		},
+		{
+			name:   "should pass if all the checks that are not mapped pass",
+			checks: map[string]config.CIStateLabels{"codecov": {}},
+			responses: []github.MockResponse{
+				github.MockListCheckRunsResponse("completed", "success"),
+				github.MockGetCombinedStatusResponse("success"),
+			},
+			expected: ciPassing,
+		},
+		{
+			name: "should fail if a check that is not mapped fails",
+			responses: []github.MockResponse{
+				github.MockListCheckRunsResponse("completed", "success"),
+				github.MockGetCombinedStatusResponse("success"),
+			},
+			expected: ciFailing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						CI: config.CILabels{
+							CIStateLabels: config.CIStateLabels{Passing: "ci:passing", Failing: "ci:failing"},
+							Checks:        tt.checks,
+						},
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			actual, err := labeler.combinedState("6dcb09b5b57875f334f61aebed695e2e4193db5e")
+			testutil.AssertError(t, false, nil, err)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package labeler
+
+import (
+	"regexp"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const defaultConventionalCommitsContext = "conventional-commits"
+
+var conventionalTitleRegexp = regexp.MustCompile(`^(\w+)(?:\(([^()]+)\))?(!)?: \S.*$`)
+
+// conventionalTitle is the struct to represent the parts of a title written in the Conventional Commits form
+type conventionalTitle struct {
+	Type     string
+	Scopes   slices.StringSlice
+	Breaking bool
+}
+
+// parseConventionalTitle parses a title in the form `type(scope1,scope2)!: description`. The scopes and the breaking
+// change marker are optional. It returns false if the title is not in the expected form
+func parseConventionalTitle(title string) (conventionalTitle, bool) {
+	matches := conventionalTitleRegexp.FindStringSubmatch(strings.TrimSpace(title))
+	if matches == nil {
+		return conventionalTitle{}, false
+	}
+
+	var scopes slices.StringSlice
+	if matches[2] != "" {
+		for _, scope := range strings.Split(matches[2], ",") {
+			scopes = scopes.Add(strings.TrimSpace(scope))
+		}
+	}
+	return conventionalTitle{
+		Type:     strings.ToLower(matches[1]),
+		Scopes:   scopes,
+		Breaking: matches[3] != "",
+	}, true
+}
+
+// conventionalLabels parses the title of the given pull request and returns the labels mapped to its type, scopes and
+// breaking change marker. If configured it also posts a commit status to the head of the pull request with the result
+// of the parsing
+func (l *Labeler) conventionalLabels(pr *gh.PullRequest) (slices.StringSlice, error) {
+	cfg := l.PullRequestsLabelerConfig.ConventionalCommits
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+
+	parsed, ok := parseConventionalTitle(pr.GetTitle())
+	if cfg.Status.Enabled {
+		statusContext := cfg.Status.Context
+		if statusContext == "" {
+			statusContext = defaultConventionalCommitsContext
+		}
+		state, description := "success", "The title is a valid Conventional Commit"
+		if !ok {
+			state, description = "failure", "The title must be in the form `type(scope): description`"
+		}
+		if err := l.Repo.CreateStatus(pr.GetHead().GetSHA(), state, statusContext, description); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	var labels slices.StringSlice
+	if label, exists := cfg.Types[parsed.Type]; exists {
+		labels = labels.Add(label)
+	}
+	for _, scope := range parsed.Scopes {
+		if label, exists := cfg.Scopes[scope]; exists && !labels.HasString(label) {
+			labels = labels.Add(label)
+		}
+	}
+	if parsed.Breaking && cfg.Breaking != "" {
+		labels = labels.Add(cfg.Breaking)
+	}
+	return labels, nil
+}
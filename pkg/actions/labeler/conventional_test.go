@@ -0,0 +1,151 @@
+package labeler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func TestParseConventionalTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		expected conventionalTitle
+		ok       bool
+	}{
+		{
+			name:     "should parse a title with type only",
+			title:    "fix: handle empty payloads",
+			expected: conventionalTitle{Type: "fix"},
+			ok:       true,
+		},
+		{
+			name:  "should parse a title with scope and breaking change marker",
+			title: "feat(api)!: remove v1 endpoints",
+			expected: conventionalTitle{
+				Type:     "feat",
+				Scopes:   []string{"api"},
+				Breaking: true,
+			},
+			ok: true,
+		},
+		{
+			name:  "should parse a title with many scopes",
+			title: "Docs(api, cli): update examples",
+			expected: conventionalTitle{
+				Type:   "docs",
+				Scopes: []string{"api", "cli"},
+			},
+			ok: true,
+		},
+		{
+			name:  "should not parse a title without type",
+			title: "Update the README with new information.",
+		},
+		{
+			name:  "should not parse a title without description",
+			title: "feat(api): ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, ok := parseConventionalTitle(tt.title)
+			if ok != tt.ok {
+				t.Errorf("Expect ok: %t Got: %t", tt.ok, ok)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLabeler_conventionalLabels(t *testing.T) {
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	conventionalCommits := config.ConventionalCommits{
+		Types:    map[string]string{"feat": "enhancement", "fix": "bug"},
+		Scopes:   map[string]string{"api": "area:api", "cli": "area:cli"},
+		Breaking: "breaking-change",
+	}
+	withStatus := conventionalCommits
+	withStatus.Status = config.ConventionalCommitsStatus{Enabled: true}
+
+	tests := []struct {
+		name                string
+		title               string
+		conventionalCommits config.ConventionalCommits
+		responses           []github.MockResponse
+		wantErr             bool
+		expectedError       error
+		expectedLabels      slices.StringSlice
+	}{
+		{
+			name:                "should return the labels mapped to the title parts",
+			title:               "feat(api,cli)!: new endpoints",
+			conventionalCommits: conventionalCommits,
+			expectedLabels:      []string{"enhancement", "area:api", "area:cli", "breaking-change"},
+		},
+		{
+			name:                "should ignore unmapped parts",
+			title:               "chore(deps): bump yaml",
+			conventionalCommits: conventionalCommits,
+		},
+		{
+			name:  "should do nothing if not configured",
+			title: "feat(api): new endpoint",
+		},
+		{
+			name:                "should post a success status if the title parses",
+			title:               "fix: something",
+			conventionalCommits: withStatus,
+			responses:           []github.MockResponse{github.MockGenericSuccessResponse()},
+			expectedLabels:      []string{"bug"},
+		},
+		{
+			name:                "should post a failure status if the title does not parse",
+			title:               "something",
+			conventionalCommits: withStatus,
+			responses:           []github.MockResponse{github.MockGenericSuccessResponse()},
+		},
+		{
+			name:                "should error if the status cannot be posted",
+			title:               "something",
+			conventionalCommits: withStatus,
+			responses:           []github.MockResponse{github.UnAuthorizedMockResponse()},
+			wantErr:             true,
+			expectedError: errors.New("cannot create status conventional-commits for 6dcb09b5b57875f334f61aebed695e2e4193db5e. " +
+				"error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						ConventionalCommits: tt.conventionalCommits,
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			actual, err := labeler.conventionalLabels(&gh.PullRequest{
+				Title: &tt.title,
+				Head:  &gh.PullRequestBranch{SHA: &sha},
+			})
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
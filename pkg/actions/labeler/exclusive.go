@@ -0,0 +1,41 @@
+package labeler
+
+import (
+	gh "github.com/google/go-github/v27/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// resolveExclusiveGroups makes sure that exactly one label of each exclusive group that applies to the given target is
+// assigned to the issue/pull request. The label of a `labeled` event (if any) is considered the newest one
+func (l *Labeler) resolveExclusiveGroups(number int, target, action string, eventLabel *gh.Label) error {
+	issue := github.NewIssue(l.Repo, number)
+	merr := new(multierror.Error)
+	for _, group := range l.ExclusiveGroups {
+		if !group.AppliesTo(target) {
+			continue
+		}
+		preferred := preferredLabel(issue, group, action, eventLabel)
+		merr = multierror.Append(merr, issue.ExactlyOne(group.Labels, group.Default, preferred))
+	}
+	return merr.ErrorOrNil()
+}
+
+// preferredLabel returns the function that picks the label of the group to keep among the assigned ones. With the
+// precedence resolution no label is preferred so the first assigned label in the group order is kept. Otherwise the
+// label of a `labeled` event is kept or, for any other event, the most recently added of the assigned labels
+func preferredLabel(issue github.Issue, group config.ExclusiveGroup, action string,
+	eventLabel *gh.Label) func(slices.StringSlice) (string, error) {
+	return func(assigned slices.StringSlice) (string, error) {
+		switch {
+		case group.Resolution == config.PrecedenceResolution:
+			return "", nil
+		case action == "labeled" && assigned.HasString(eventLabel.GetName()):
+			return eventLabel.GetName(), nil
+		}
+		return issue.LatestLabel(assigned)
+	}
+}
@@ -0,0 +1,222 @@
+package labeler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const webhookIssueLabeledPayload = `
+{
+  "action": "labeled",
+  "label": {
+    "name": "enhancement"
+  },
+  "issue": {
+    "id": 444500041,
+    "node_id": "MDU6SXNzdWU0NDQ1MDAwNDE=",
+    "number": 1,
+    "title": "Some random issue"
+  }
+}`
+
+func TestLabeler_resolveExclusiveGroups(t *testing.T) {
+	type args struct {
+		payload   []byte
+		eventName string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		groups        []config.ExclusiveGroup
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should keep the label of the labeled event",
+			args: args{
+				payload:   []byte(webhookIssueLabeledPayload),
+				eventName: "issues",
+			},
+			groups: []config.ExclusiveGroup{
+				{Labels: []string{"bug", "enhancement"}},
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should look up the newest label if the event has no label",
+			args: args{
+				payload:   []byte(webhookPayload),
+				eventName: "pull_request",
+			},
+			groups: []config.ExclusiveGroup{
+				{Labels: []string{"bug", "enhancement"}, Target: config.PullRequestsTarget},
+			},
+			responses: []github.MockResponse{
+				// labeler run
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				// exclusive group
+				github.MockListIssueLabelsResponse(),
+				github.MockListIssueEventsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should not look up the newest label if precedence decides",
+			args: args{
+				payload:   []byte(webhookIssueLabeledPayload),
+				eventName: "issues",
+			},
+			groups: []config.ExclusiveGroup{
+				{Labels: []string{"bug", "enhancement"}, Resolution: config.PrecedenceResolution},
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should not look up the newest label if only one label of the group is assigned",
+			args: args{
+				payload:   []byte(webhookIssueLabeledPayload),
+				eventName: "issues",
+			},
+			groups: []config.ExclusiveGroup{
+				{Labels: []string{"bug", "question"}},
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+			},
+		},
+		{
+			name: "should skip groups of other targets",
+			args: args{
+				payload:   []byte(webhookIssueLabeledPayload),
+				eventName: "issues",
+			},
+			groups: []config.ExclusiveGroup{
+				{Labels: []string{"bug", "enhancement"}, Target: config.PullRequestsTarget},
+			},
+		},
+		{
+			name: "should return error if the newest label cannot be looked up",
+			args: args{
+				payload:   []byte(webhookPayload),
+				eventName: "pull_request",
+			},
+			groups: []config.ExclusiveGroup{
+				{Labels: []string{"bug", "enhancement"}},
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("1 error occurred:\n\t* cannot list events of issue 2. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/2/events?per_page=100: 401 Bad credentials []\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						Actions: []string{"opened"},
+					},
+					ExclusiveGroups: tt.groups,
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			err := labeler.HandleEvent(tt.args.eventName, &tt.args.payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestPreferredLabel(t *testing.T) {
+	eventsResponse := github.MockResponse{
+		StatusCode: http.StatusOK,
+		Response: `[
+  {"event": "labeled", "label": {"name": "enhancement"}},
+  {"event": "labeled", "label": {"name": "bug"}},
+  {"event": "labeled", "label": {"name": "question"}},
+  {"event": "unlabeled", "label": {"name": "question"}}
+]`,
+	}
+	bug := "bug"
+	tests := []struct {
+		name          string
+		group         config.ExclusiveGroup
+		action        string
+		responses     []github.MockResponse
+		expected      string
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:     "should prefer the label of a labeled event",
+			group:    config.ExclusiveGroup{Labels: []string{"enhancement", "bug", "question"}},
+			action:   "labeled",
+			expected: "bug",
+		},
+		{
+			name:      "should prefer the newest assigned label if the event label was removed",
+			group:     config.ExclusiveGroup{Labels: []string{"bug", "enhancement", "question"}},
+			action:    "unlabeled",
+			responses: []github.MockResponse{github.MockListIssueEventsResponse()},
+			expected:  "enhancement",
+		},
+		{
+			name:      "should ignore the newer labels that are no longer assigned",
+			group:     config.ExclusiveGroup{Labels: []string{"enhancement", "bug", "question"}},
+			action:    "edited",
+			responses: []github.MockResponse{eventsResponse},
+			expected:  "bug",
+		},
+		{
+			name:   "should prefer no label if precedence decides",
+			group:  config.ExclusiveGroup{Labels: []string{"enhancement", "bug"}, Resolution: config.PrecedenceResolution},
+			action: "labeled",
+		},
+		{
+			name:          "should error if the newest label cannot be looked up",
+			group:         config.ExclusiveGroup{Labels: []string{"bug", "enhancement"}},
+			action:        "edited",
+			responses:     []github.MockResponse{github.UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot list events of issue 1. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1/events?per_page=100: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := github.NewIssue(github.Repo{
+				GHClient: github.MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1)
+			preferred := preferredLabel(issue, tt.group, tt.action, &gh.Label{Name: &bug})
+			actual, err := preferred(slices.StringSlice{"bug", "enhancement"})
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
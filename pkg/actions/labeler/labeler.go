@@ -2,6 +2,7 @@ package labeler
 
 import (
 	"log"
+	"sort"
 
 	gh "github.com/google/go-github/v27/github"
 	"github.com/hashicorp/go-multierror"
@@ -9,6 +10,8 @@ import (
 	"github.com/ppapapetrou76/virtual-assistant/pkg/actions"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/glob"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
 )
 
 // Labeler is the struct to handle auto-labeling of issues, PRs etc.
@@ -31,9 +34,34 @@ func (l *Labeler) HandleEvent(eventName string, payload *[]byte) error {
 		if actions.ShouldRunOnPullRequest(event, l.PullRequestsLabelerConfig.Actions) {
 			err = l.runOn(event.PullRequest)
 		}
+		if err == nil {
+			err = l.removeLabels(event.PullRequest.GetNumber(), event.GetAction(), config.PullRequestsTarget)
+		}
+		if err == nil {
+			err = l.resolveExclusiveGroups(event.PullRequest.GetNumber(), config.PullRequestsTarget, event.GetAction(), event.Label)
+		}
+		if err == nil {
+			err = l.updateReviewLabel(event.PullRequest)
+		}
+	case *gh.PullRequestReviewEvent:
+		err = l.updateReviewLabel(event.PullRequest)
+	case *gh.CheckSuiteEvent:
+		suite := event.GetCheckSuite()
+		err = l.updateCILabels(suite.GetHeadSHA(), "", checkState(suite.GetStatus(), suite.GetConclusion()))
+	case *gh.CheckRunEvent:
+		run := event.GetCheckRun()
+		err = l.updateCILabels(run.GetHeadSHA(), run.GetName(), checkState(run.GetStatus(), run.GetConclusion()))
+	case *gh.StatusEvent:
+		err = l.updateCILabels(event.GetSHA(), event.GetContext(), statusState(event.GetState()))
 	case *gh.IssuesEvent:
-		if actions.ShouldRunOnIssue(event, l.PullRequestsLabelerConfig.Actions) {
-			err = l.runOnIssue(event.Issue)
+		if actions.ShouldRunOnIssue(event, l.IssuesLabelerConfig.Actions) {
+			err = l.runOnIssue(event.Issue, issueAuthorAssociation(payload))
+		}
+		if err == nil {
+			err = l.removeLabels(event.Issue.GetNumber(), event.GetAction(), config.IssuesTarget)
+		}
+		if err == nil {
+			err = l.resolveExclusiveGroups(event.Issue.GetNumber(), config.IssuesTarget, event.GetAction(), event.Label)
 		}
 	}
 	return err
@@ -47,12 +75,81 @@ func (l *Labeler) runOn(pr *gh.PullRequest) error {
 		return err
 	}
 
+	files, err := l.changedFiles(pullRequest)
+	if err != nil {
+		return err
+	}
+
+	matchedLabels, err := regexLabels(l.PullRequestsLabelerConfig.RegexLabels, pr.GetTitle(), pr.GetBody())
+	if err != nil {
+		return err
+	}
+
+	conventionalLabels, err := l.conventionalLabels(pr)
+	if err != nil {
+		return err
+	}
+
+	matchedBranchLabels, err := branchLabels(l.PullRequestsLabelerConfig.BranchLabels, pr)
+	if err != nil {
+		return err
+	}
+
+	matchedAuthorLabels, err := l.authorLabels(config.PullRequestsTarget, pr.GetUser(), pr.GetAuthorAssociation())
+	if err != nil {
+		return err
+	}
+
+	linkedIssueLabels := l.linkedIssueLabels(pullRequest, pr.GetBody())
+
+	if l.PullRequestsLabelerConfig.Size.IsEnabled() {
+		// only one size label should exist so any previous size label is dropped
+		currLabels = currLabels.Remove(sizeLabels(l.PullRequestsLabelerConfig.Size)...)
+		currLabels = currLabels.Add(sizeLabel(l.PullRequestsLabelerConfig.Size, pr, files))
+	}
+
 	desiredLabels := append(l.PullRequestsLabelerConfig.Labels, currLabels...)
+	desiredLabels = append(desiredLabels, l.fileLabels(files)...)
+	desiredLabels = append(desiredLabels, matchedLabels...)
+	desiredLabels = append(desiredLabels, conventionalLabels...)
+	desiredLabels = append(desiredLabels, matchedBranchLabels...)
+	desiredLabels = append(desiredLabels, matchedAuthorLabels...)
+	desiredLabels = append(desiredLabels, linkedIssueLabels...)
 	log.Printf("Desired labels: %s", desiredLabels)
-	return pullRequest.ReplaceLabels(desiredLabels)
+
+	merr := new(multierror.Error)
+	merr = multierror.Append(merr, pullRequest.ReplaceLabels(desiredLabels))
+	merr = multierror.Append(merr, atLeastOne(pullRequest, l.PullRequestsLabelerConfig.AtLeastOne))
+
+	return merr.ErrorOrNil()
+}
+
+// changedFiles returns the files changed by the pull request only if any of the configured features needs them so
+// that no api calls are made otherwise
+func (l *Labeler) changedFiles(pullRequest github.Issue) ([]*gh.CommitFile, error) {
+	cfg := l.PullRequestsLabelerConfig
+	if len(cfg.Files) == 0 && (!cfg.Size.IsEnabled() || cfg.Size.Ignore.IsEmpty()) {
+		return nil, nil
+	}
+	return pullRequest.Files()
+}
+
+// fileLabels returns the labels whose glob patterns match at least one of the given files
+func (l *Labeler) fileLabels(files []*gh.CommitFile) slices.StringSlice {
+	var labels slices.StringSlice
+	for label, patterns := range l.PullRequestsLabelerConfig.Files {
+		for _, f := range files {
+			if glob.MatchAny(patterns, f.GetFilename()) {
+				labels = labels.Add(label)
+				break
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
 }
 
-func (l *Labeler) runOnIssue(i *gh.Issue) error {
+func (l *Labeler) runOnIssue(i *gh.Issue, authorAssociation string) error {
 	issue := github.NewIssue(l.Repo, *i.Number)
 	currLabels, err := issue.CurrentLabels()
 
@@ -60,13 +157,36 @@ func (l *Labeler) runOnIssue(i *gh.Issue) error {
 		return err
 	}
 
+	matchedLabels, err := regexLabels(l.IssuesLabelerConfig.RegexLabels, i.GetTitle(), i.GetBody())
+	if err != nil {
+		return err
+	}
+
+	matchedAuthorLabels, err := l.authorLabels(config.IssuesTarget, i.GetUser(), authorAssociation)
+	if err != nil {
+		return err
+	}
+
 	desiredLabels := append(l.IssuesLabelerConfig.Labels, currLabels...)
+	desiredLabels = append(desiredLabels, matchedLabels...)
+	desiredLabels = append(desiredLabels, matchedAuthorLabels...)
+	desiredLabels = append(desiredLabels, templateLabels(l.IssuesLabelerConfig.TemplateLabels, i.GetBody())...)
 	log.Printf("Desired labels: %s", desiredLabels)
 
 	merr := new(multierror.Error)
 	merr = multierror.Append(merr, issue.ReplaceLabels(desiredLabels))
-	merr = multierror.Append(merr, issue.AtLeastOne(l.IssuesLabelerConfig.PossibleLabels, l.IssuesLabelerConfig.Default))
+	merr = multierror.Append(merr, atLeastOne(issue, l.IssuesLabelerConfig.AtLeastOne))
+
+	return merr.ErrorOrNil()
+}
 
+// atLeastOne checks each one of the given groups independently and adds its default label if none of its labels is
+// assigned to the issue/pull request
+func atLeastOne(issue github.Issue, groups config.OneOfaKindGroups) error {
+	merr := new(multierror.Error)
+	for _, group := range groups {
+		merr = multierror.Append(merr, issue.AtLeastOne(group.PossibleLabels, group.Default))
+	}
 	return merr.ErrorOrNil()
 }
 
@@ -2,11 +2,15 @@ package labeler
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
+	gh "github.com/google/go-github/v27/github"
+
 	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
 	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
 )
 
 const webhookPayload = `{
@@ -36,7 +40,8 @@ const webhookIssuePayload = `
 
 func TestLabeler_HandleEvent(t *testing.T) {
 	type fields struct {
-		repo github.Repo
+		repo   github.Repo
+		config *config.LabelerConfig
 	}
 	type args struct {
 		labels    []string
@@ -89,6 +94,45 @@ func TestLabeler_HandleEvent(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should run on the issue actions of the issues config",
+			args: args{
+				payload:   []byte(webhookIssuePayload),
+				eventName: "issues",
+			},
+			fields: fields{
+				config: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{Actions: []string{"labeled"}},
+					IssuesLabelerConfig:       config.IssuesLabelerConfig{Labels: []string{"feature"}, Actions: []string{"opened"}},
+				},
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{
+						github.MockListIssueLabelsResponse(),
+						github.MockGenericSuccessResponse(),
+					}),
+					Owner: "ppapapetrou76",
+					Name:  "virtual-assistant",
+				},
+			},
+		},
+		{
+			name: "should skip the issue actions that are not in the issues config",
+			args: args{
+				payload:   []byte(webhookIssuePayload),
+				eventName: "issues",
+			},
+			fields: fields{
+				config: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{Actions: []string{"opened"}},
+					IssuesLabelerConfig:       config.IssuesLabelerConfig{Labels: []string{"feature"}, Actions: []string{"edited"}},
+				},
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{}),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			},
+		},
 		{
 			name: "should return error if event is pull request and fetching current label fails",
 			args: args{
@@ -127,6 +171,121 @@ func TestLabeler_HandleEvent(t *testing.T) {
 			wantErr:       true,
 			expectedError: errors.New("GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1/labels: 401 Bad credentials []"),
 		},
+		{
+			name: "should handle a pr event with file labels",
+			args: args{
+				payload:   []byte(webhookPayload),
+				eventName: "pull_request",
+			},
+			fields: fields{
+				config: filesConfig(),
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{
+						github.MockGenericSuccessResponse(),
+						github.MockListPullRequestFilesResponse(),
+						github.MockGenericSuccessResponse(),
+					}),
+					Owner: "ppapapetrou76",
+					Name:  "virtual-assistant",
+				},
+			},
+		},
+		{
+			name: "should return error if event is pull request and listing the changed files fails",
+			args: args{
+				payload:   []byte(webhookPayload),
+				eventName: "pull_request",
+			},
+			fields: fields{
+				config: filesConfig(),
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{
+						github.MockGenericSuccessResponse(),
+						github.UnAuthorizedMockResponse(),
+					}),
+					Owner: "ppapapetrou76",
+					Name:  "virtual-assistant",
+				},
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot list files of pull request 2. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/2/files?per_page=100: 401 Bad credentials []"),
+		},
+		{
+			name: "should return error if event is issue and a regex rule is invalid",
+			args: args{
+				payload:   []byte(webhookIssuePayload),
+				eventName: "issues",
+			},
+			fields: fields{
+				config: &config.LabelerConfig{
+					IssuesLabelerConfig: config.IssuesLabelerConfig{
+						RegexLabels: []config.RegexLabel{{Label: "crash", Pattern: "crash("}},
+					},
+				},
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{
+						github.MockGenericSuccessResponse(),
+					}),
+					Owner: "ppapapetrou76",
+					Name:  "virtual-assistant",
+				},
+			},
+			wantErr:       true,
+			expectedError: errors.New("invalid regular expression `crash(` for label crash. error message : error parsing regexp: missing closing ): `crash(`"),
+		},
+		{
+			name: "should handle a pr event with size labels",
+			args: args{
+				payload:   []byte(webhookPayload),
+				eventName: "pull_request",
+			},
+			fields: fields{
+				config: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						Size: config.SizeLabels{XS: 10, S: 30, M: 100, L: 500, Ignore: []string{"docs/**"}},
+					},
+				},
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{
+						github.MockListIssueLabelsResponse(),
+						github.MockListPullRequestFilesResponse(),
+						github.MockGenericSuccessResponse(),
+					}),
+					Owner: "ppapapetrou76",
+					Name:  "virtual-assistant",
+				},
+			},
+		},
+		{
+			name: "should handle a pr event with at least one label groups",
+			args: args{
+				payload:   []byte(webhookPayload),
+				eventName: "pull_request",
+			},
+			fields: fields{
+				config: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						AtLeastOne: config.OneOfaKindGroups{
+							{PossibleLabels: []string{"bug", "feature"}, Default: "feature"},
+							{PossibleLabels: []string{"release-note:yes", "release-note:no"}, Default: "release-note:yes"},
+						},
+					},
+				},
+				repo: github.Repo{
+					GHClient: github.MockGithubClient([]github.MockResponse{
+						github.MockListIssueLabelsResponse(),
+						github.MockGenericSuccessResponse(),
+						// first group is satisfied by the bug label
+						github.MockListIssueLabelsResponse(),
+						// second group adds its default label
+						github.MockListIssueLabelsResponse(),
+						github.MockGenericSuccessResponse(),
+					}),
+					Owner: "ppapapetrou76",
+					Name:  "virtual-assistant",
+				},
+			},
+		},
 		{
 			name: "should return error parsing webhook",
 			args: args{
@@ -147,19 +306,73 @@ func TestLabeler_HandleEvent(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			labelerConfig := tt.fields.config
+			if labelerConfig == nil {
+				labelerConfig = defaultConfig()
+			}
 			labeler := Labeler{
-				LabelerConfig: &config.LabelerConfig{
-					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
-						Labels: []string{"bug"},
-					},
-					IssuesLabelerConfig: config.IssuesLabelerConfig{
-						Labels: []string{"feature"},
-					},
-				},
-				Repo: tt.fields.repo,
+				LabelerConfig: labelerConfig,
+				Repo:          tt.fields.repo,
 			}
 			err := labeler.HandleEvent(tt.args.eventName, &tt.args.payload)
 			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
 		})
 	}
 }
+
+func TestLabeler_fileLabels(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *config.LabelerConfig
+		files          []string
+		expectedLabels slices.StringSlice
+	}{
+		{
+			name:           "should return the labels matching the changed files",
+			config:         filesConfig(),
+			files:          []string{"docs/README.md", "pkg/github/issue.go"},
+			expectedLabels: []string{"area:docs", "area:github"},
+		},
+		{
+			name:   "should return no labels if no file labels are configured",
+			config: defaultConfig(),
+			files:  []string{"docs/README.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: tt.config,
+			}
+			files := make([]*gh.CommitFile, 0, len(tt.files))
+			for i := range tt.files {
+				files = append(files, &gh.CommitFile{Filename: &tt.files[i]})
+			}
+			actual := labeler.fileLabels(files)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
+
+func defaultConfig() *config.LabelerConfig {
+	return &config.LabelerConfig{
+		PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+			Labels: []string{"bug"},
+		},
+		IssuesLabelerConfig: config.IssuesLabelerConfig{
+			Labels: []string{"feature"},
+		},
+	}
+}
+
+func filesConfig() *config.LabelerConfig {
+	c := defaultConfig()
+	c.PullRequestsLabelerConfig.Files = map[string]slices.StringSlice{
+		"area:docs":   {"docs/**", "*.md"},
+		"area:github": {"pkg/github/**"},
+		"area:cmd":    {"cmd/**"},
+	}
+	return c
+}
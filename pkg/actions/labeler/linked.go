@@ -0,0 +1,35 @@
+package labeler
+
+import (
+	"log"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/glob"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// linkedIssueLabels returns the labels of the issues that the pull request body resolves, keeping only the ones that
+// match the configured patterns. Linked issues that can't be read (e.g. issues of private repositories that the token
+// has no access to) are skipped so that they don't block the rest of the pull request labels
+func (l *Labeler) linkedIssueLabels(pullRequest github.Issue, body string) slices.StringSlice {
+	patterns := l.PullRequestsLabelerConfig.LinkedIssues.Labels
+	if patterns.IsEmpty() {
+		return nil
+	}
+
+	var labels slices.StringSlice
+	for _, issue := range pullRequest.LinkedIssues(body) {
+		issueLabels, err := issue.CurrentLabels()
+		if err != nil {
+			log.Printf("Cannot get the labels of linked issue %s/%s#%d. Skipping it : %s",
+				issue.Owner, issue.Name, issue.Number, err.Error())
+			continue
+		}
+		for _, label := range issueLabels {
+			if glob.MatchAny(patterns, label) && !labels.HasString(label) {
+				labels = labels.Add(label)
+			}
+		}
+	}
+	return labels
+}
@@ -0,0 +1,71 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func TestLabeler_linkedIssueLabels(t *testing.T) {
+	tests := []struct {
+		name           string
+		patterns       slices.StringSlice
+		body           string
+		responses      []github.MockResponse
+		expectedLabels slices.StringSlice
+	}{
+		{
+			name:     "should copy the matching labels of the linked issues",
+			patterns: []string{"enh*"},
+			body:     "Fixes #1 and closes myorg/other#2",
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockListIssueLabelsResponse(),
+			},
+			expectedLabels: []string{"enhancement"},
+		},
+		{
+			name: "should do nothing if no patterns are configured",
+			body: "Fixes #1",
+		},
+		{
+			name:     "should do nothing if no issues are linked",
+			patterns: []string{"*"},
+			body:     "Some description",
+		},
+		{
+			name:     "should skip the linked issues whose labels cannot be retrieved",
+			patterns: []string{"*"},
+			body:     "Closes otherorg/private#4\nFixes #1",
+			responses: []github.MockResponse{
+				github.MockNotFoundResponse(),
+				github.MockListIssueLabelsResponse(),
+			},
+			expectedLabels: []string{"bug", "enhancement"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := github.Repo{
+				GHClient: github.MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						LinkedIssues: config.LinkedIssuesLabels{Labels: tt.patterns},
+					},
+				},
+				Repo: repo,
+			}
+			actual := labeler.linkedIssueLabels(github.NewIssue(repo, 2), tt.body)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
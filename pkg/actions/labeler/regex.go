@@ -0,0 +1,50 @@
+package labeler
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const (
+	titleField = "title"
+	bodyField  = "body"
+)
+
+// regexLabels returns the labels of the rules that match the given title and body
+func regexLabels(rules []config.RegexLabel, title, body string) (slices.StringSlice, error) {
+	var labels slices.StringSlice
+	for _, rule := range rules {
+		matched, err := regexMatches(rule, title, body)
+		if err != nil {
+			return nil, err
+		}
+		if matched && !labels.HasString(rule.Label) {
+			labels = labels.Add(rule.Label)
+		}
+	}
+	return labels, nil
+}
+
+func regexMatches(rule config.RegexLabel, title, body string) (bool, error) {
+	pattern := rule.Pattern
+	if rule.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression `%s` for label %s. error message : %s",
+			rule.Pattern, rule.Label, err.Error())
+	}
+
+	fields := rule.Fields
+	if fields.IsEmpty() {
+		fields = slices.StringSlice{titleField, bodyField}
+	}
+
+	matched := (fields.HasString(titleField) && re.MatchString(title)) ||
+		(fields.HasString(bodyField) && re.MatchString(body))
+	return matched != rule.Negate, nil
+}
@@ -0,0 +1,100 @@
+package labeler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func TestRegexLabels(t *testing.T) {
+	type args struct {
+		rules []config.RegexLabel
+		title string
+		body  string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantErr        bool
+		expectedError  error
+		expectedLabels slices.StringSlice
+	}{
+		{
+			name: "should add the label if the title or the body matches",
+			args: args{
+				rules: []config.RegexLabel{
+					{Label: "crash", Pattern: "crash|panic"},
+					{Label: "regression", Pattern: "used to work"},
+				},
+				title: "App crash on startup",
+				body:  "It used to work with the previous version",
+			},
+			expectedLabels: []string{"crash", "regression"},
+		},
+		{
+			name: "should only check the configured fields",
+			args: args{
+				rules: []config.RegexLabel{
+					{Label: "crash", Pattern: "crash", Fields: []string{"title"}},
+				},
+				title: "Something is wrong",
+				body:  "crash",
+			},
+		},
+		{
+			name: "should ignore the case if configured",
+			args: args{
+				rules: []config.RegexLabel{
+					{Label: "security", Pattern: "cve-[0-9]+", IgnoreCase: true},
+					{Label: "crash", Pattern: "crash"},
+				},
+				title: "Fix CVE-2020 and CRASH",
+			},
+			expectedLabels: []string{"security"},
+		},
+		{
+			name: "should add the label if the pattern doesn't match and the rule is negated",
+			args: args{
+				rules: []config.RegexLabel{
+					{Label: "needs-description", Pattern: ".+", Fields: []string{"body"}, Negate: true},
+				},
+				title: "Some title",
+			},
+			expectedLabels: []string{"needs-description"},
+		},
+		{
+			name: "should add each label once",
+			args: args{
+				rules: []config.RegexLabel{
+					{Label: "crash", Pattern: "crash"},
+					{Label: "crash", Pattern: "panic"},
+				},
+				title: "panic and crash",
+			},
+			expectedLabels: []string{"crash"},
+		},
+		{
+			name: "should error if the pattern is invalid",
+			args: args{
+				rules: []config.RegexLabel{
+					{Label: "crash", Pattern: "crash("},
+				},
+			},
+			wantErr:       true,
+			expectedError: errors.New("invalid regular expression `crash(` for label crash. error message : error parsing regexp: missing closing ): `crash(`"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := regexLabels(tt.args.rules, tt.args.title, tt.args.body)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package labeler
+
+import (
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// removeLabels removes the labels of all the removal rules that apply to the given event action and target
+func (l *Labeler) removeLabels(number int, action, target string) error {
+	var labels slices.StringSlice
+	for _, rule := range l.RemovalRules {
+		if rule.AppliesTo(action, target) {
+			labels = append(labels, rule.Labels...)
+		}
+	}
+	if labels.IsEmpty() {
+		return nil
+	}
+	return github.NewIssue(l.Repo, number).RemoveLabels(labels...)
+}
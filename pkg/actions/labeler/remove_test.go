@@ -0,0 +1,113 @@
+package labeler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+const webhookIssueAssignedPayload = `
+{
+  "action": "assigned",
+  "issue": {
+    "id": 444500041,
+    "node_id": "MDU6SXNzdWU0NDQ1MDAwNDE=",
+    "number": 1,
+    "title": "Some random issue"
+  }
+}`
+
+const webhookReadyForReviewPayload = `{
+  "action": "ready_for_review",
+  "number": 2,
+  "pull_request": {
+    "id": 279147437,
+    "node_id": "MDExOlB1bGxSZXF1ZXN0Mjc5MTQ3NDM3",
+    "number": 2,
+    "state": "open",
+    "title": "Update the README with new information."
+   }
+}`
+
+func TestLabeler_removeLabels(t *testing.T) {
+	removalRules := []config.RemovalRule{
+		{Labels: []string{"bug"}, Actions: []string{"assigned"}, Target: config.IssuesTarget},
+		{Labels: []string{"enhancement"}, Actions: []string{"ready_for_review"}, Target: config.PullRequestsTarget},
+	}
+	type args struct {
+		payload   []byte
+		eventName string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should remove the labels of the issue rules",
+			args: args{
+				payload:   []byte(webhookIssueAssignedPayload),
+				eventName: "issues",
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should remove the labels of the pull request rules",
+			args: args{
+				payload:   []byte(webhookReadyForReviewPayload),
+				eventName: "pull_request",
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should only run the labeler if no rule applies to the event action",
+			args: args{
+				payload:   []byte(webhookIssuePayload),
+				eventName: "issues",
+			},
+			responses: []github.MockResponse{
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should return error if the current labels cannot be retrieved",
+			args: args{
+				payload:   []byte(webhookIssueAssignedPayload),
+				eventName: "issues",
+			},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1/labels: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					RemovalRules: removalRules,
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			err := labeler.HandleEvent(tt.args.eventName, &tt.args.payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
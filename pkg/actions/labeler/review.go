@@ -0,0 +1,70 @@
+package labeler
+
+import (
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+)
+
+const (
+	reviewApproved         = "APPROVED"
+	reviewChangesRequested = "CHANGES_REQUESTED"
+	reviewDismissed        = "DISMISSED"
+)
+
+// updateReviewLabel replaces the review state label of the pull request with the one matching its current reviews.
+// Pull requests that are not open are skipped
+func (l *Labeler) updateReviewLabel(pr *gh.PullRequest) error {
+	cfg := l.PullRequestsLabelerConfig.Reviews
+	if !cfg.IsEnabled() || pr.GetState() != "open" {
+		return nil
+	}
+
+	pullRequest := github.NewIssue(l.Repo, pr.GetNumber())
+	reviews, err := pullRequest.Reviews()
+	if err != nil {
+		return err
+	}
+	currLabels, err := pullRequest.CurrentLabels()
+	if err != nil {
+		return err
+	}
+
+	desiredLabels := currLabels.Remove(cfg.NeedsReview, cfg.ChangesRequested, cfg.Approved)
+	if label := reviewLabel(cfg, reviews); label != "" {
+		desiredLabels = desiredLabels.Add(label)
+	}
+	return pullRequest.ReplaceLabels(desiredLabels)
+}
+
+// reviewLabel returns the label of the review state of a pull request. Only the latest approval or change request of
+// each reviewer counts and dismissed reviews are ignored. Any change request wins over approvals
+func reviewLabel(cfg config.ReviewLabels, reviews []*gh.PullRequestReview) string {
+	states := map[string]string{}
+	for _, review := range reviews {
+		switch review.GetState() {
+		case reviewApproved, reviewChangesRequested:
+			states[review.GetUser().GetLogin()] = review.GetState()
+		case reviewDismissed:
+			delete(states, review.GetUser().GetLogin())
+		}
+	}
+
+	approvals := 0
+	for _, state := range states {
+		if state == reviewChangesRequested {
+			return cfg.ChangesRequested
+		}
+		approvals++
+	}
+
+	required := cfg.RequiredApprovals
+	if required <= 0 {
+		required = 1
+	}
+	if approvals >= required {
+		return cfg.Approved
+	}
+	return cfg.NeedsReview
+}
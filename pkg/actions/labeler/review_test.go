@@ -0,0 +1,194 @@
+package labeler
+
+import (
+	"errors"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+const webhookReviewPayload = `{
+  "action": "submitted",
+  "review": {
+    "id": 84,
+    "user": {
+      "login": "octocat"
+    },
+    "state": "approved"
+  },
+  "pull_request": {
+    "id": 279147437,
+    "number": 2,
+    "state": "open",
+    "title": "Update the README with new information."
+  }
+}`
+
+func TestReviewLabel(t *testing.T) {
+	cfg := config.ReviewLabels{
+		NeedsReview:      "needs-review",
+		ChangesRequested: "changes-requested",
+		Approved:         "approved",
+	}
+	twoApprovals := cfg
+	twoApprovals.RequiredApprovals = 2
+
+	tests := []struct {
+		name     string
+		config   config.ReviewLabels
+		reviews  []*gh.PullRequestReview
+		expected string
+	}{
+		{
+			name:     "should need review if there are no reviews",
+			config:   cfg,
+			expected: "needs-review",
+		},
+		{
+			name:   "should need review if there are only comments",
+			config: cfg,
+			reviews: []*gh.PullRequestReview{
+				review("octocat", "COMMENTED"),
+			},
+			expected: "needs-review",
+		},
+		{
+			name:   "should request changes if any reviewer requested changes",
+			config: cfg,
+			reviews: []*gh.PullRequestReview{
+				review("octocat", "APPROVED"),
+				review("hubot", "CHANGES_REQUESTED"),
+			},
+			expected: "changes-requested",
+		},
+		{
+			name:   "should only count the latest review of each reviewer",
+			config: cfg,
+			reviews: []*gh.PullRequestReview{
+				review("hubot", "CHANGES_REQUESTED"),
+				review("hubot", "COMMENTED"),
+				review("hubot", "APPROVED"),
+			},
+			expected: "approved",
+		},
+		{
+			name:   "should ignore dismissed reviews",
+			config: cfg,
+			reviews: []*gh.PullRequestReview{
+				review("hubot", "CHANGES_REQUESTED"),
+				review("hubot", "DISMISSED"),
+			},
+			expected: "needs-review",
+		},
+		{
+			name:   "should need review until the required approvals are given",
+			config: twoApprovals,
+			reviews: []*gh.PullRequestReview{
+				review("hubot", "APPROVED"),
+				review("hubot", "APPROVED"),
+			},
+			expected: "needs-review",
+		},
+		{
+			name:   "should approve when the required approvals are given",
+			config: twoApprovals,
+			reviews: []*gh.PullRequestReview{
+				review("hubot", "APPROVED"),
+				review("octocat", "APPROVED"),
+			},
+			expected: "approved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := reviewLabel(tt.config, tt.reviews)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+const webhookClosedPullRequestPayload = `{
+  "action": "closed",
+  "number": 2,
+  "pull_request": {
+    "id": 279147437,
+    "number": 2,
+    "state": "closed",
+    "title": "Update the README with new information."
+  }
+}`
+
+func TestLabeler_updateReviewLabel(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventName     string
+		payload       string
+		config        config.ReviewLabels
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:   "should replace the review label",
+			config: config.ReviewLabels{NeedsReview: "needs-review", Approved: "approved"},
+			responses: []github.MockResponse{
+				github.MockListReviewsResponse(),
+				github.MockListIssueLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should do nothing if review labels are not configured",
+		},
+		{
+			name:      "should do nothing if the pull request is not open",
+			eventName: "pull_request",
+			payload:   webhookClosedPullRequestPayload,
+			config:    config.ReviewLabels{NeedsReview: "needs-review", Approved: "approved"},
+		},
+		{
+			name:   "should error if the reviews cannot be listed",
+			config: config.ReviewLabels{NeedsReview: "needs-review"},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot list reviews of pull request 2. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/2/reviews?per_page=100: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labeler := Labeler{
+				LabelerConfig: &config.LabelerConfig{
+					PullRequestsLabelerConfig: config.PullRequestsLabelerConfig{
+						Reviews: tt.config,
+					},
+				},
+				Repo: github.Repo{
+					GHClient: github.MockGithubClient(tt.responses),
+					Owner:    "ppapapetrou76",
+					Name:     "virtual-assistant",
+				},
+			}
+			eventName, payload := "pull_request_review", []byte(webhookReviewPayload)
+			if tt.eventName != "" {
+				eventName, payload = tt.eventName, []byte(tt.payload)
+			}
+			err := labeler.HandleEvent(eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func review(login, state string) *gh.PullRequestReview {
+	return &gh.PullRequestReview{
+		User:  &gh.User{Login: &login},
+		State: &state,
+	}
+}
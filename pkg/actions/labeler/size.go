@@ -0,0 +1,48 @@
+package labeler
+
+import (
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/glob"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const defaultSizePrefix = "size/"
+
+var sizeNames = []string{"XS", "S", "M", "L", "XL"}
+
+// sizeLabels returns all the possible size labels of the given configuration
+func sizeLabels(cfg config.SizeLabels) slices.StringSlice {
+	prefix := cfg.Prefix
+	if prefix == "" {
+		prefix = defaultSizePrefix
+	}
+	labels := make(slices.StringSlice, 0, len(sizeNames))
+	for _, name := range sizeNames {
+		labels = labels.Add(prefix + name)
+	}
+	return labels
+}
+
+// sizeLabel returns the size label of a pull request based on the number of changed lines. If there are ignore
+// patterns then the changed lines are computed from the given files, otherwise the pull request totals are used
+func sizeLabel(cfg config.SizeLabels, pr *gh.PullRequest, files []*gh.CommitFile) string {
+	changes := pr.GetAdditions() + pr.GetDeletions()
+	if !cfg.Ignore.IsEmpty() {
+		changes = 0
+		for _, f := range files {
+			if !glob.MatchAny(cfg.Ignore, f.GetFilename()) {
+				changes += f.GetAdditions() + f.GetDeletions()
+			}
+		}
+	}
+
+	labels := sizeLabels(cfg)
+	for i, threshold := range []int{cfg.XS, cfg.S, cfg.M, cfg.L} {
+		if changes <= threshold {
+			return labels[i]
+		}
+	}
+	return labels[len(labels)-1]
+}
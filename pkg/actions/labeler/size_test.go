@@ -0,0 +1,105 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+func TestSizeLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   config.SizeLabels
+		expected slices.StringSlice
+	}{
+		{
+			name:     "should use the default prefix",
+			expected: []string{"size/XS", "size/S", "size/M", "size/L", "size/XL"},
+		},
+		{
+			name:     "should use the configured prefix",
+			config:   config.SizeLabels{Prefix: "size:"},
+			expected: []string{"size:XS", "size:S", "size:M", "size:L", "size:XL"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := sizeLabels(tt.config)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSizeLabel(t *testing.T) {
+	thresholds := config.SizeLabels{XS: 10, S: 30, M: 100, L: 500}
+	withIgnore := thresholds
+	withIgnore.Ignore = []string{"vendor/**", "**/*.pb.go"}
+
+	type args struct {
+		additions int
+		deletions int
+		files     []*gh.CommitFile
+	}
+	tests := []struct {
+		name     string
+		config   config.SizeLabels
+		args     args
+		expected string
+	}{
+		{
+			name:     "should return XS for tiny pull requests",
+			config:   thresholds,
+			args:     args{additions: 5, deletions: 5},
+			expected: "size/XS",
+		},
+		{
+			name:     "should return M for medium pull requests",
+			config:   thresholds,
+			args:     args{additions: 60, deletions: 10},
+			expected: "size/M",
+		},
+		{
+			name:     "should return XL for pull requests above the L threshold",
+			config:   thresholds,
+			args:     args{additions: 480, deletions: 21},
+			expected: "size/XL",
+		},
+		{
+			name:   "should not count the ignored files",
+			config: withIgnore,
+			args: args{
+				additions: 1000,
+				deletions: 1000,
+				files: []*gh.CommitFile{
+					commitFile("vendor/github.com/lib/lib.go", 900, 900),
+					commitFile("pkg/api/api.pb.go", 90, 90),
+					commitFile("pkg/api/api.go", 10, 10),
+				},
+			},
+			expected: "size/S",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &gh.PullRequest{Additions: &tt.args.additions, Deletions: &tt.args.deletions}
+			actual := sizeLabel(tt.config, pr, tt.args.files)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func commitFile(name string, additions, deletions int) *gh.CommitFile {
+	return &gh.CommitFile{
+		Filename:  &name,
+		Additions: &additions,
+		Deletions: &deletions,
+	}
+}
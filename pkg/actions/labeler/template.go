@@ -0,0 +1,113 @@
+package labeler
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+// noResponse is the text issue forms render for optional fields that were left empty
+const noResponse = "_No response_"
+
+var (
+	headingRegexp  = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	checkboxRegexp = regexp.MustCompile(`^[-*]\s+\[([ xX])\]\s+(.+)$`)
+)
+
+// templateSection is the struct to represent a section of an issue body, i.e. the lines under a heading
+type templateSection struct {
+	Answers slices.StringSlice
+	Checked slices.StringSlice
+}
+
+// parseTemplate splits an issue body into sections keyed by their lower case heading. Content before the first
+// heading is keyed by an empty string. Plain lines are considered answers and dropdowns with many selected values
+// (rendered comma separated) are split into one answer per value
+func parseTemplate(body string) map[string]*templateSection {
+	current := &templateSection{}
+	sections := map[string]*templateSection{"": current}
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == noResponse {
+			continue
+		}
+		if matches := headingRegexp.FindStringSubmatch(line); matches != nil {
+			current = &templateSection{}
+			sections[strings.ToLower(matches[1])] = current
+			continue
+		}
+		if matches := checkboxRegexp.FindStringSubmatch(line); matches != nil {
+			if matches[1] != " " {
+				current.Checked = current.Checked.Add(strings.ToLower(matches[2]))
+			}
+			continue
+		}
+		for _, answer := range strings.Split(line, ",") {
+			current.Answers = current.Answers.Add(strings.ToLower(strings.TrimSpace(answer)))
+		}
+	}
+	return sections
+}
+
+// templateLabels returns the labels of the rules that match the sections, answers and ticked checkboxes of the
+// given issue body
+func templateLabels(rules []config.TemplateLabel, body string) slices.StringSlice {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	sections := parseTemplate(body)
+	var labels slices.StringSlice
+	add := func(label string) {
+		if label != "" && !labels.HasString(label) {
+			labels = labels.Add(label)
+		}
+	}
+	for _, rule := range rules {
+		if rule.Checkbox != "" {
+			if checkboxTicked(sections, rule) {
+				add(rule.Label)
+			}
+			continue
+		}
+
+		section, ok := sections[strings.ToLower(rule.Section)]
+		if !ok || rule.Section == "" {
+			continue
+		}
+		if len(rule.Values) == 0 {
+			if !section.Answers.IsEmpty() {
+				add(rule.Label)
+			}
+			continue
+		}
+		values := make([]string, 0, len(rule.Values))
+		for value := range rule.Values {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			if section.Answers.HasString(strings.ToLower(value)) {
+				add(rule.Values[value])
+			}
+		}
+	}
+	return labels
+}
+
+func checkboxTicked(sections map[string]*templateSection, rule config.TemplateLabel) bool {
+	checkbox := strings.ToLower(rule.Checkbox)
+	if rule.Section != "" {
+		section, ok := sections[strings.ToLower(rule.Section)]
+		return ok && section.Checked.HasString(checkbox)
+	}
+	for _, section := range sections {
+		if section.Checked.HasString(checkbox) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package labeler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const issueFormBody = `### Component
+
+Parser, CLI
+
+### Severity
+
+High
+
+### Logs
+
+_No response_
+
+### Stack trace
+
+panic: runtime error
+
+### Checklist
+
+- [X] This is a regression
+- [ ] I can provide a fix
+`
+
+func TestTemplateLabels(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          []config.TemplateLabel
+		body           string
+		expectedLabels slices.StringSlice
+	}{
+		{
+			name: "should add the labels of the selected values",
+			rules: []config.TemplateLabel{
+				{Section: "Component", Values: map[string]string{"parser": "area:parser", "CLI": "area:cli", "Infra": "area:infra"}},
+				{Section: "Severity", Values: map[string]string{"High": "priority:1", "Low": "priority:3"}},
+			},
+			body:           issueFormBody,
+			expectedLabels: []string{"area:cli", "area:parser", "priority:1"},
+		},
+		{
+			name: "should add the labels of the ticked checkboxes",
+			rules: []config.TemplateLabel{
+				{Checkbox: "This is a regression", Label: "regression"},
+				{Checkbox: "I can provide a fix", Label: "contribution"},
+				{Section: "Component", Checkbox: "This is a regression", Label: "misplaced"},
+			},
+			body:           issueFormBody,
+			expectedLabels: []string{"regression"},
+		},
+		{
+			name: "should add the labels of the answered sections",
+			rules: []config.TemplateLabel{
+				{Section: "Stack trace", Label: "crash"},
+				{Section: "Logs", Label: "has-logs"},
+				{Section: "Missing", Label: "missing"},
+			},
+			body:           issueFormBody,
+			expectedLabels: []string{"crash"},
+		},
+		{
+			name: "should support Markdown templates",
+			rules: []config.TemplateLabel{
+				{Section: "Component", Values: map[string]string{"parser": "area:parser"}},
+				{Checkbox: "Breaking change", Label: "breaking-change"},
+			},
+			body:           "Some description\n\n## Component ##\nparser\n\n* [x] Breaking change",
+			expectedLabels: []string{"area:parser", "breaking-change"},
+		},
+		{
+			name: "should not add labels if there are no rules",
+			body: issueFormBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := templateLabels(tt.rules, tt.body)
+			if !reflect.DeepEqual(actual, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package labelsync
+
+import (
+	"log"
+	"strings"
+
+	gh "github.com/google/go-github/v27/github"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
+)
+
+const (
+	defaultEvent = "push"
+	// defaultColor is the color GitHub gives to labels created without one
+	defaultColor = "ededed"
+)
+
+// Synchronizer is the struct to handle the synchronization of the repository labels with the declared ones
+type Synchronizer struct {
+	*config.LabelSyncConfig
+	github.Repo
+}
+
+// HandleEvent synchronizes the repository labels if the given event name is one of the configured events. Pushes are
+// only synchronized if they are on the configured branch, as the configuration is loaded from the pushed commit
+func (s *Synchronizer) HandleEvent(eventName string, payload *[]byte) error {
+	if len(s.LabelSyncConfig.Labels) == 0 {
+		return nil
+	}
+	events := s.Events
+	if events.IsEmpty() {
+		events = slices.StringSlice{defaultEvent}
+	}
+	if !events.HasString(eventName) {
+		log.Printf("Event is `%s` - eligible events are `%v`. Skipping label synchronization", eventName, events)
+		return nil
+	}
+	if eventName == defaultEvent {
+		event, err := gh.ParseWebHook(eventName, *payload)
+		if err != nil {
+			return err
+		}
+		if !s.isSyncBranch(event.(*gh.PushEvent)) {
+			return nil
+		}
+	}
+	return s.sync()
+}
+
+// isSyncBranch returns true if the given push is on the configured branch or, if there is none, on the default branch
+// of the repository
+func (s *Synchronizer) isSyncBranch(event *gh.PushEvent) bool {
+	branch := s.Branch
+	if branch == "" {
+		branch = event.GetRepo().GetDefaultBranch()
+	}
+	if ref := event.GetRef(); ref != "refs/heads/"+branch {
+		log.Printf("Push is on `%s` - eligible branch is `%s`. Skipping label synchronization", ref, branch)
+		return false
+	}
+	return true
+}
+
+// sync creates, updates or renames the declared labels and, if pruning is enabled, deletes the labels that are not
+// declared. Label names are compared case-insensitively as GitHub does
+func (s *Synchronizer) sync() error {
+	labels, err := s.Repo.Labels()
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*gh.Label, len(labels))
+	for _, label := range labels {
+		existing[strings.ToLower(label.GetName())] = label
+	}
+
+	merr := new(multierror.Error)
+	for _, definition := range s.LabelSyncConfig.Labels {
+		color := normalizeColor(definition.Color)
+		current := s.find(existing, definition)
+		if current == nil {
+			if color == "" {
+				color = defaultColor
+			}
+			merr = multierror.Append(merr, s.Repo.CreateLabel(definition.Name, color, definition.Description))
+			continue
+		}
+		delete(existing, strings.ToLower(current.GetName()))
+		// colors and descriptions that are not declared are left untouched
+		if color == "" {
+			color = current.GetColor()
+		}
+		description := definition.Description
+		if description == "" {
+			description = current.GetDescription()
+		}
+		if current.GetName() != definition.Name || current.GetColor() != color || current.GetDescription() != description {
+			merr = multierror.Append(merr, s.Repo.EditLabel(current.GetName(), definition.Name, color, description))
+		}
+	}
+
+	if s.Prune {
+		for _, label := range labels {
+			if _, undeclared := existing[strings.ToLower(label.GetName())]; undeclared {
+				merr = multierror.Append(merr, s.Repo.DeleteLabel(label.GetName()))
+			}
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// find returns the existing label with the name of the given definition or, if it doesn't exist, the existing label
+// with any of its aliases
+func (s *Synchronizer) find(existing map[string]*gh.Label, definition config.LabelDefinition) *gh.Label {
+	for _, name := range append(slices.StringSlice{definition.Name}, definition.Aliases...) {
+		if label, ok := existing[strings.ToLower(name)]; ok {
+			return label
+		}
+	}
+	return nil
+}
+
+func normalizeColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
+
+// New creates a new label synchronizer object
+func New(c *config.Config, repo github.Repo) *Synchronizer {
+	return &Synchronizer{
+		LabelSyncConfig: &c.LabelSyncConfig,
+		Repo:            repo,
+	}
+}
@@ -0,0 +1,158 @@
+package labelsync
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/config"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/github"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func pushPayload(branch string) string {
+	return fmt.Sprintf(`{"ref": "refs/heads/%s", "repository": {"default_branch": "main"}}`, branch)
+}
+
+func TestSynchronizer_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventName     string
+		payload       string
+		config        config.LabelSyncConfig
+		responses     []github.MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should do nothing if no labels are declared",
+			eventName: "push",
+			payload:   pushPayload("main"),
+		},
+		{
+			name:      "should do nothing if the event is not configured",
+			eventName: "issues",
+			payload:   `{}`,
+			config: config.LabelSyncConfig{
+				Labels: []config.LabelDefinition{{Name: "bug"}},
+			},
+		},
+		{
+			name:      "should do nothing if the push is not on the default branch",
+			eventName: "push",
+			payload:   pushPayload("feature"),
+			config: config.LabelSyncConfig{
+				Prune:  true,
+				Labels: []config.LabelDefinition{{Name: "bug"}},
+			},
+		},
+		{
+			name:      "should do nothing if the push is not on the configured branch",
+			eventName: "push",
+			payload:   pushPayload("main"),
+			config: config.LabelSyncConfig{
+				Branch: "labels",
+				Labels: []config.LabelDefinition{{Name: "bug"}},
+			},
+		},
+		{
+			name:      "should sync the labels on pushes on the configured branch",
+			eventName: "push",
+			payload:   pushPayload("labels"),
+			config: config.LabelSyncConfig{
+				Branch: "labels",
+				Labels: []config.LabelDefinition{{Name: "bug", Color: "f29513", Description: "Something isn't working"}},
+			},
+			responses: []github.MockResponse{
+				github.MockListRepositoryLabelsResponse(),
+			},
+		},
+		{
+			name:      "should do nothing if the labels are in sync",
+			eventName: "push",
+			payload:   pushPayload("main"),
+			config: config.LabelSyncConfig{
+				Labels: []config.LabelDefinition{
+					{Name: "bug", Color: "#F29513", Description: "Something isn't working"},
+					{Name: "enhancement"},
+				},
+			},
+			responses: []github.MockResponse{
+				github.MockListRepositoryLabelsResponse(),
+			},
+		},
+		{
+			name:      "should create, update and rename labels",
+			eventName: "label",
+			payload:   `{}`,
+			config: config.LabelSyncConfig{
+				Events: []string{"label"},
+				Labels: []config.LabelDefinition{
+					{Name: "type:bug", Color: "d73a4a", Aliases: []string{"bug"}},
+					{Name: "enhancement", Color: "a2eeef", Description: "A new feature"},
+					{Name: "question", Color: "d876e3"},
+				},
+			},
+			responses: []github.MockResponse{
+				github.MockListRepositoryLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should delete undeclared labels if prune is enabled",
+			eventName: "push",
+			payload:   pushPayload("main"),
+			config: config.LabelSyncConfig{
+				Prune: true,
+				Labels: []config.LabelDefinition{
+					{Name: "bug", Color: "f29513", Description: "Something isn't working"},
+				},
+			},
+			responses: []github.MockResponse{
+				github.MockListRepositoryLabelsResponse(),
+				github.MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name:      "should return error if the repository labels cannot be retrieved",
+			eventName: "push",
+			payload:   pushPayload("main"),
+			config: config.LabelSyncConfig{
+				Labels: []config.LabelDefinition{{Name: "bug"}},
+			},
+			responses: []github.MockResponse{
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get repository (ppapapetrou76/virtual-assistant) labels. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/labels?per_page=100: 401 Bad credentials []"),
+		},
+		{
+			name:      "should return error if a label cannot be created",
+			eventName: "push",
+			payload:   pushPayload("main"),
+			config: config.LabelSyncConfig{
+				Labels: []config.LabelDefinition{{Name: "question"}},
+			},
+			responses: []github.MockResponse{
+				github.MockListRepositoryLabelsResponse(),
+				github.UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("1 error occurred:\n\t* cannot create label question. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/labels: 401 Bad credentials []\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			synchronizer := New(&config.Config{LabelSyncConfig: tt.config}, github.Repo{
+				GHClient: github.MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			})
+			payload := []byte(tt.payload)
+			err := synchronizer.HandleEvent(tt.eventName, &payload)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
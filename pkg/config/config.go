@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/go-yaml/yaml"
 
@@ -11,21 +12,115 @@ import (
 
 // Config is the struct to hold user configuration
 type Config struct {
-	LabelerConfig  `yaml:"labeler"`
-	AssignerConfig `yaml:"assigner"`
+	LabelerConfig       `yaml:"labeler"`
+	AssignerConfig      `yaml:"assigner"`
+	LabelSyncConfig     `yaml:"label-sync"`
+	MergeConflictConfig `yaml:"merge-conflict"`
+	CommandsConfig      `yaml:"commands"`
 }
 
+const (
+	// IssuesTarget is the value to use in rules that apply only to issues
+	IssuesTarget = "issues"
+	// PullRequestsTarget is the value to use in rules that apply only to pull requests
+	PullRequestsTarget = "pull-requests"
+)
+
 // LabelerConfig is the struct to hold user configuration for the labeler
 type LabelerConfig struct {
 	IssuesLabelerConfig       `yaml:"issues"`
 	PullRequestsLabelerConfig `yaml:"pull-requests"`
+	RemovalRules              []RemovalRule    `yaml:"remove"`
+	ExclusiveGroups           []ExclusiveGroup `yaml:"exactly-one"`
+	AuthorLabels              []AuthorLabel    `yaml:"authors"`
+}
+
+// AuthorLabel is the struct to hold user configuration related to the feature of adding a label to issues and/or pull
+// requests based on their author. All the given conditions must be true for the label to be added
+type AuthorLabel struct {
+	Label string
+	// Associations is a list of author associations with the repository (e.g. `FIRST_TIME_CONTRIBUTOR`,
+	// `CONTRIBUTOR`, `MEMBER`, `OWNER`). The author must have any of them
+	Associations slices.StringSlice
+	// Bot requires the author to be a bot account
+	Bot bool
+	// MemberOf requires the author to be a member of the given organization
+	MemberOf string `yaml:"member-of"`
+	// NotMemberOf requires the author not to be a member of the given organization
+	NotMemberOf string `yaml:"not-member-of"`
+	// Target is either `issues` or `pull-requests`. If empty the rule applies to both
+	Target string
+}
+
+// AppliesTo returns true if the rule should run for the given target
+func (a AuthorLabel) AppliesTo(target string) bool {
+	return matchesTarget(a.Target, target)
+}
+
+// RemovalRule is the struct to hold user configuration related to the feature of removing labels from issues and/or
+// pull requests when an event with one of the given actions happens (e.g. remove `needs-triage` when an issue is
+// `assigned`)
+type RemovalRule struct {
+	Labels  slices.StringSlice
+	Actions slices.StringSlice
+	// Target is either `issues` or `pull-requests`. If empty the rule applies to both
+	Target string
+}
+
+// AppliesTo returns true if the rule should run for the given event action and target
+func (r RemovalRule) AppliesTo(action, target string) bool {
+	return r.Actions.HasString(action) && matchesTarget(r.Target, target)
+}
+
+const (
+	// NewestResolution keeps the most recently added label of an exclusive group
+	NewestResolution = "newest"
+	// PrecedenceResolution keeps the label of an exclusive group that comes first in the group labels list
+	PrecedenceResolution = "precedence"
+)
+
+// ExclusiveGroup is the struct to hold user configuration related to the feature of keeping exactly one label of a
+// group (e.g. `priority:1`, `priority:2`) on issues and/or pull requests. If more than one label of the group is
+// assigned then only one is kept according to the resolution and if none is assigned then the default label is added
+type ExclusiveGroup struct {
+	Labels  slices.StringSlice
+	Default string
+	// Resolution is either `newest` (the default) or `precedence`
+	Resolution string
+	// Target is either `issues` or `pull-requests`. If empty the group applies to both
+	Target string
+}
+
+// AppliesTo returns true if the group should run for the given target
+func (g ExclusiveGroup) AppliesTo(target string) bool {
+	return matchesTarget(g.Target, target)
+}
+
+func matchesTarget(configured, target string) bool {
+	return configured == "" || configured == target
 }
 
 // IssuesLabelerConfig is the struct to hold user configuration related to issues labeler
 type IssuesLabelerConfig struct {
-	Labels     slices.StringSlice
-	Actions    slices.StringSlice
-	OneOfaKind `yaml:"at-least-one"`
+	Labels         slices.StringSlice
+	Actions        slices.StringSlice
+	AtLeastOne     OneOfaKindGroups `yaml:"at-least-one"`
+	RegexLabels    []RegexLabel     `yaml:"regex"`
+	TemplateLabels []TemplateLabel  `yaml:"template"`
+}
+
+// TemplateLabel is the struct to hold user configuration related to the feature of adding labels based on the
+// structured body of issues created from issue forms or Markdown templates
+type TemplateLabel struct {
+	// Section is the text of a body heading (e.g. `Component` for `### Component`)
+	Section string
+	// Values maps an answer of the section (e.g. a dropdown value) to a label
+	Values map[string]string
+	// Checkbox is the text of a checkbox that must be ticked for the label to be added. If a section is given the
+	// checkbox is looked up only in that section
+	Checkbox string
+	// Label is added if the checkbox is ticked or, if there's no checkbox and no values, if the section is answered
+	Label string
 }
 
 // OneOfaKind is the struct to hold user configuration related to the feature of checking the existence of at least
@@ -35,22 +130,351 @@ type OneOfaKind struct {
 	Default        string
 }
 
+// OneOfaKindGroups is a list of independent OneOfaKind groups. It can be configured either as a single group or as a
+// list of groups
+type OneOfaKindGroups []OneOfaKind
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface so that both a single group and a list of groups are accepted
+func (g *OneOfaKindGroups) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var groups []OneOfaKind
+	if err := unmarshal(&groups); err == nil {
+		*g = groups
+		return nil
+	}
+
+	var group OneOfaKind
+	if err := unmarshal(&group); err != nil {
+		return err
+	}
+	*g = OneOfaKindGroups{group}
+	return nil
+}
+
 // PullRequestsLabelerConfig is the struct to hold user configuration related to pull-requests labeler
 type PullRequestsLabelerConfig struct {
-	Labels  slices.StringSlice
-	Actions slices.StringSlice
+	Labels     slices.StringSlice
+	Actions    slices.StringSlice
+	AtLeastOne OneOfaKindGroups `yaml:"at-least-one"`
+	// Files maps a label to a list of glob patterns. The label is added if any of the changed files matches any of
+	// the patterns
+	Files               map[string]slices.StringSlice `yaml:"files"`
+	RegexLabels         []RegexLabel                  `yaml:"regex"`
+	ConventionalCommits `yaml:"conventional-commits"`
+	Size                SizeLabels         `yaml:"size"`
+	BranchLabels        []BranchLabel      `yaml:"branches"`
+	LinkedIssues        LinkedIssuesLabels `yaml:"linked-issues"`
+	Reviews             ReviewLabels       `yaml:"reviews"`
+	CI                  CILabels           `yaml:"ci"`
+}
+
+// CILabels is the struct to hold user configuration related to the feature of keeping a label with the state of the
+// checks and statuses of pull requests. The top level labels reflect the combined state of all the checks that are not
+// listed in Checks: failing if any of them fails, otherwise pending if any of them is pending, otherwise passing
+type CILabels struct {
+	CIStateLabels `yaml:",inline"`
+	// Checks maps a check run name or a status context to its own labels, which reflect the latest state of that check
+	// only. A check mapped to no labels is ignored
+	Checks map[string]CIStateLabels `yaml:"checks"`
+}
+
+// CIStateLabels is the struct to hold the labels of each state of a check or status
+type CIStateLabels struct {
+	Passing string
+	Failing string
+	Pending string
+}
+
+// IsEnabled returns true if any of the state labels is configured
+func (c CIStateLabels) IsEnabled() bool {
+	return c.Passing != "" || c.Failing != "" || c.Pending != ""
+}
+
+// IsEnabled returns true if any of the state labels is configured for any check
+func (c CILabels) IsEnabled() bool {
+	if c.CIStateLabels.IsEnabled() {
+		return true
+	}
+	for _, labels := range c.Checks {
+		if labels.IsEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMapped returns true if the given check is mapped to its own labels, i.e. it's not part of the top level labels
+func (c CILabels) IsMapped(check string) bool {
+	_, ok := c.Checks[check]
+	return ok
+}
+
+// For returns the state labels of the given check
+func (c CILabels) For(check string) CIStateLabels {
+	if labels, ok := c.Checks[check]; ok {
+		return labels
+	}
+	return c.CIStateLabels
+}
+
+// ReviewLabels is the struct to hold user configuration related to the feature of keeping a label with the review
+// state of pull requests
+type ReviewLabels struct {
+	NeedsReview      string `yaml:"needs-review"`
+	ChangesRequested string `yaml:"changes-requested"`
+	Approved         string
+	// RequiredApprovals is the number of approvals for a pull request to be considered approved. It defaults to 1
+	RequiredApprovals int `yaml:"required-approvals"`
+}
+
+// IsEnabled returns true if any of the review state labels is configured
+func (r ReviewLabels) IsEnabled() bool {
+	return r.NeedsReview != "" || r.ChangesRequested != "" || r.Approved != ""
+}
+
+// LinkedIssuesLabels is the struct to hold user configuration related to the feature of copying labels from the issues
+// a pull request resolves (e.g. `Fixes #123` or `Closes org/repo#45`) to the pull request
+type LinkedIssuesLabels struct {
+	// Labels is a list of glob patterns (e.g. `area:*`) of the issue labels to copy
+	Labels slices.StringSlice
+}
+
+// BranchLabel is the struct to hold user configuration related to the feature of adding a label to pull requests based
+// on their head and/or base branch names. If both the head and the base patterns are given then both must match
+type BranchLabel struct {
+	Label string
+	Head  string
+	Base  string
+	// Regex makes the head and base patterns regular expressions instead of glob patterns
+	Regex bool
+}
+
+// SizeLabels is the struct to hold user configuration related to the feature of labeling pull requests based on the
+// number of changed lines (additions plus deletions). Each threshold is the maximum number of changed lines of its
+// size and pull requests with more changed lines than the `l` threshold are labeled as XL
+type SizeLabels struct {
+	XS int `yaml:"xs"`
+	S  int `yaml:"s"`
+	M  int `yaml:"m"`
+	L  int `yaml:"l"`
+	// Prefix is prepended to the size name to build the label (e.g. `size/XL`)
+	Prefix string
+	// Ignore is a list of glob patterns of files that are not taken into account (e.g. generated or vendored files)
+	Ignore slices.StringSlice
+}
+
+// IsEnabled returns true if the size thresholds are configured
+func (s SizeLabels) IsEnabled() bool {
+	return s.XS > 0 && s.S > 0 && s.M > 0 && s.L > 0
+}
+
+// ConventionalCommits is the struct to hold user configuration related to the feature of parsing pull request titles
+// written in the Conventional Commits form (e.g. `feat(api)!: add endpoint`) and adding labels based on their parts
+type ConventionalCommits struct {
+	// Types maps a commit type (e.g. `feat`) to a label
+	Types map[string]string
+	// Scopes maps a commit scope (e.g. `api`) to a label
+	Scopes map[string]string
+	// Breaking is the label to add if the title has the breaking change marker `!`
+	Breaking string
+	Status   ConventionalCommitsStatus
+}
+
+// ConventionalCommitsStatus is the struct to hold user configuration related to the commit status posted to the head
+// of the pull request depending on whether its title is a valid Conventional Commit
+type ConventionalCommitsStatus struct {
+	Enabled bool
+	Context string
+}
+
+// IsEnabled returns true if any of the conventional commits features is configured
+func (c ConventionalCommits) IsEnabled() bool {
+	return len(c.Types) > 0 || len(c.Scopes) > 0 || c.Breaking != "" || c.Status.Enabled
+}
+
+// RegexLabel is the struct to hold user configuration related to the feature of adding a label if the title and/or
+// the body of an issue/pull request matches a regular expression
+type RegexLabel struct {
+	Label   string
+	Pattern string
+	// Fields is the list of fields to match the pattern against. Valid values are `title` and `body`. If empty both
+	// fields are checked
+	Fields     slices.StringSlice
+	IgnoreCase bool `yaml:"ignore-case"`
+	// Negate adds the label if the pattern doesn't match any of the fields
+	Negate bool
+}
+
+// LabelSyncConfig is the struct to hold user configuration for the synchronization of the repository labels
+type LabelSyncConfig struct {
+	Labels []LabelDefinition
+	// Prune deletes the repository labels that are not declared
+	Prune bool
+	// Events is the list of event names (e.g. `push`) that trigger the synchronization
+	Events slices.StringSlice
+	// Branch is the only branch whose pushes trigger the synchronization, so that unmerged configurations of other
+	// branches can't change the labels. It defaults to the default branch of the repository
+	Branch string
+}
+
+// LabelDefinition is the struct to hold user configuration related to a repository label
+type LabelDefinition struct {
+	Name        string
+	Color       string
+	Description string
+	// Aliases is a list of old names of the label. An existing label with any of these names is renamed
+	Aliases slices.StringSlice
+}
+
+// MergeConflictConfig is the struct to hold user configuration for the detection of pull requests with merge conflicts
+type MergeConflictConfig struct {
+	// Label is added to the pull requests that have conflicts with their base branch
+	Label string
+	// Comment is an optional comment added to the pull requests when a conflict is detected
+	Comment string
+	// Retries is the number of times the mergeable state is checked again while GitHub computes it, as long as the
+	// total wait doesn't exceed 30 seconds. It defaults to 5
+	Retries int
+}
+
+// CommandsConfig is the struct to hold user configuration for the slash commands (e.g. `/label bug`) of issue and
+// pull request comments
+type CommandsConfig struct {
+	// Enabled is the list of enabled commands: `label`, `unlabel`, `assign` and `priority`
+	Enabled slices.StringSlice
+	// Permission is the minimum repository permission (`read`, `triage`, `write`, `maintain` or `admin`) of the
+	// commenters that can run commands. It defaults to `write`
+	Permission string
+	// PriorityPrefix is the prefix of the labels set by the priority command. It defaults to `priority:`
+	PriorityPrefix string `yaml:"priority-prefix"`
 }
 
 // AssignerConfig is the struct to hold user configuration for the assigner
 type AssignerConfig struct {
 	IssuesAssignerConfig       `yaml:"issues"`
 	PullRequestsAssignerConfig `yaml:"pull-requests"`
+	Availability               AvailabilityConfig `yaml:"availability"`
+	Milestone                  MilestoneConfig    `yaml:"milestone"`
+}
+
+const (
+	// FixedMilestone sets the milestone with the configured name
+	FixedMilestone = "fixed"
+	// NextDueMilestone sets the open milestone with the closest due date that hasn't passed
+	NextDueMilestone = "next-due"
+	// BaseBranchMilestone sets the milestone named after the base branch of pull requests, e.g. `1.4` for
+	// `release/1.4`
+	BaseBranchMilestone = "base-branch"
+)
+
+// MilestoneConfig is the struct to hold user configuration related to setting the milestone of issues and/or pull
+// requests that don't have one
+type MilestoneConfig struct {
+	// Strategy is either `fixed`, `next-due` or `base-branch`. Issues are skipped by the `base-branch` strategy as
+	// they have no base branch
+	Strategy string
+	// Name is the name of the milestone of the `fixed` strategy
+	Name string
+	// BranchPrefix is the prefix that the `base-branch` strategy strips from the base branch to get the milestone
+	// name. It defaults to `release/` and pull requests of base branches without it are skipped
+	BranchPrefix string `yaml:"branch-prefix"`
+	// Create creates the missing milestones of the `fixed` and `base-branch` strategies
+	Create bool
+	// Actions are the event actions that set the milestone, `opened` by default. Closed pull requests are skipped
+	// unless they are merged
+	Actions slices.StringSlice
+	// Target is either `issues` or `pull-requests`. If empty the milestone is set on both
+	Target string
+}
+
+// AppliesTo returns true if a milestone strategy is configured for the given target
+func (m MilestoneConfig) AppliesTo(target string) bool {
+	return m.Strategy != "" && matchesTarget(m.Target, target)
+}
+
+// dateLayout is the layout of the dates of the configuration, e.g. `2020-12-24`
+const dateLayout = "2006-01-02"
+
+// AvailabilityConfig is the struct to hold user configuration related to the dates that people are unavailable (e.g.
+// out of office) so that the assigner skips them
+type AvailabilityConfig struct {
+	// File is the path of a YAML file of the repository, in the same format as Unavailable, that is read from the
+	// default branch
+	File string
+	// Unavailable maps a user login to the date ranges they are unavailable
+	Unavailable map[string][]DateRange
+}
+
+// DateRange is the struct to hold an inclusive range of dates. If `to` is empty the range is a single day
+type DateRange struct {
+	From string
+	To   string
+}
+
+// Includes returns true if the given day is in the range. Days are compared in UTC
+func (d DateRange) Includes(day time.Time) (bool, error) {
+	from, err := time.Parse(dateLayout, d.From)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse date %s. error message : %s", d.From, err.Error())
+	}
+	to := from
+	if d.To != "" {
+		if to, err = time.Parse(dateLayout, d.To); err != nil {
+			return false, fmt.Errorf("cannot parse date %s. error message : %s", d.To, err.Error())
+		}
+	}
+	day, _ = time.Parse(dateLayout, day.UTC().Format(dateLayout))
+	return !day.Before(from) && !day.After(to), nil
 }
 
 // PullRequestsAssignerConfig is the struct to hold user configuration related to issues labeler
 type PullRequestsAssignerConfig struct {
-	Assignee PullRequestsAutoAssigneeConfig `yaml:"assignee"`
-	Actions  slices.StringSlice
+	Assignee   PullRequestsAutoAssigneeConfig `yaml:"assignee"`
+	Actions    slices.StringSlice
+	Reviewers  ReviewersConfig  `yaml:"reviewers"`
+	CodeOwners CodeOwnersConfig `yaml:"code-owners"`
+	// Project is the project that new pull requests are added to
+	Project ProjectConfig `yaml:"project"`
+}
+
+// CodeOwnersConfig is the struct to hold user configuration related to assigning pull requests to and/or requesting
+// reviews from the owners of the changed files, as declared in the CODEOWNERS file of the repository
+type CodeOwnersConfig struct {
+	// Assign assigns the pull request to the code owners. Teams can't be assignees so they are skipped unless they
+	// are expanded
+	Assign bool
+	// Review requests reviews from the code owners
+	Review bool
+	// Max is the maximum number of code owners picked per pull request. Owners of more changed files are picked
+	// first and zero means no limit
+	Max int
+	// ExpandTeams replaces the team owners with their members
+	ExpandTeams bool `yaml:"expand-teams"`
+}
+
+const (
+	// RandomStrategy picks the reviewers randomly
+	RandomStrategy = "random"
+	// RoundRobinStrategy picks the reviewers in turn, based on the pull request number
+	RoundRobinStrategy = "round-robin"
+	// LeastOpenReviewsStrategy picks the reviewers with the fewest pending review requests on open pull requests
+	LeastOpenReviewsStrategy = "least-open-reviews"
+)
+
+// ReviewersConfig is the struct to hold user configuration related to requesting reviews of pull requests from a pool
+// of users and/or teams
+type ReviewersConfig struct {
+	Users slices.StringSlice
+	// Teams is a list of team slugs of the repository organization
+	Teams slices.StringSlice
+	// Count is the number of reviewers to request. It defaults to 1
+	Count int
+	// Strategy is either `random` (the default), `round-robin` or `least-open-reviews`
+	Strategy string
+}
+
+// IsEnabled returns true if the pool of reviewers is not empty
+func (r ReviewersConfig) IsEnabled() bool {
+	return !r.Users.IsEmpty() || !r.Teams.IsEmpty()
 }
 
 // PullRequestsAutoAssigneeConfig is the struct to hold user configuration related to issues labeler
@@ -62,12 +486,57 @@ type PullRequestsAutoAssigneeConfig struct {
 type IssuesAssignerConfig struct {
 	IssuesAssignerProjectConfig `yaml:"project"`
 	Actions                     slices.StringSlice
+	// Owners maps a label to the owners of the issues with it. Owners are users (`@octocat`) or teams
+	// (`@org/team`) whose members are picked in turn
+	Owners map[string]slices.StringSlice
 }
 
 // IssuesAssignerProjectConfig is the struct to hold user configuration related to issues labeler
 type IssuesAssignerProjectConfig struct {
+	ProjectConfig `yaml:",inline"`
+	// Columns maps an issues event action (e.g. `closed` or `reopened`) to the column the card of the issue is moved
+	// to. The `linked-pull-request` key is the column of the issues that a newly opened pull request resolves
+	Columns map[string]string `yaml:"columns"`
+	// Targets routes the issues to different projects. The first target whose conditions match an issue is its
+	// project, so a target without conditions that comes last catches the rest of the issues. The project of the
+	// configuration itself is ignored if there are targets
+	Targets []ProjectTarget `yaml:"targets"`
+}
+
+// ProjectTarget is the struct to hold user configuration related to a project that issues are routed to, if they
+// match all of the given conditions
+type ProjectTarget struct {
+	ProjectConfig `yaml:",inline"`
+	// Labels matches the issues with any of the labels
+	Labels slices.StringSlice
+	// Authors matches the issues opened by any of the users
+	Authors slices.StringSlice
+	// Title is a regular expression that matches the issue titles
+	Title string
+}
+
+// ProjectConfig is the struct to hold user configuration related to the project, and its column, that issues or pull
+// requests are added to
+type ProjectConfig struct {
 	ProjectURL string `yaml:"url"`
 	Column     string `yaml:"column"`
+	// Owner and Number identify a project (v2) of an organization or a user, by default the owner of the repository.
+	// They take precedence over the url of a classic project and the columns are the values of the Status field
+	Owner  string `yaml:"owner"`
+	Number int    `yaml:"number"`
+	// Fields maps the name of a project (v2) field to the value the items get when they are added to the project, e.g.
+	// `Priority: High`. Iteration fields accept `@current` for the iteration that includes the current date
+	Fields map[string]string `yaml:"fields"`
+}
+
+// IsV2 returns true if the configuration refers to a project (v2)
+func (c ProjectConfig) IsV2() bool {
+	return c.Number != 0
+}
+
+// IsEnabled returns true if the configuration refers to a classic project or a project (v2)
+func (c ProjectConfig) IsEnabled() bool {
+	return c.ProjectURL != "" || c.IsV2()
 }
 
 // Load loads config data from raw format to a Config struct
@@ -7,10 +7,12 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-yaml/yaml"
 
 	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
 )
 
 func TestLoad(t *testing.T) {
@@ -33,6 +35,33 @@ func TestLoad(t *testing.T) {
 				fileName: "../../test_data/valid-config.yml",
 			},
 			expected: &Config{
+				LabelSyncConfig: LabelSyncConfig{
+					Prune:  true,
+					Branch: "main",
+					Events: []string{"push"},
+					Labels: []LabelDefinition{
+						{
+							Name:        "type:bug",
+							Color:       "#d73a4a",
+							Description: "Something isn't working",
+							Aliases:     []string{"bug"},
+						},
+						{
+							Name:  "type:feature",
+							Color: "a2eeef",
+						},
+					},
+				},
+				MergeConflictConfig: MergeConflictConfig{
+					Label:   "needs-rebase",
+					Comment: "This pull request has conflicts with its base branch, please rebase it",
+					Retries: 3,
+				},
+				CommandsConfig: CommandsConfig{
+					Enabled:        []string{"label", "unlabel", "assign", "priority"},
+					Permission:     "triage",
+					PriorityPrefix: "p",
+				},
 				AssignerConfig: AssignerConfig{
 					PullRequestsAssignerConfig: PullRequestsAssignerConfig{
 						Assignee: PullRequestsAutoAssigneeConfig{
@@ -42,6 +71,24 @@ func TestLoad(t *testing.T) {
 							"opened",
 							"synchronize",
 						},
+						Reviewers: ReviewersConfig{
+							Users:    []string{"octocat", "hubot"},
+							Teams:    []string{"backend"},
+							Count:    2,
+							Strategy: LeastOpenReviewsStrategy,
+						},
+						CodeOwners: CodeOwnersConfig{
+							Assign:      true,
+							Review:      true,
+							Max:         3,
+							ExpandTeams: true,
+						},
+						Project: ProjectConfig{
+							Owner:  "ppapapetrou76",
+							Number: 2,
+							Column: "In Progress",
+							Fields: map[string]string{"Priority": "High"},
+						},
 					},
 					IssuesAssignerConfig: IssuesAssignerConfig{
 						Actions: []string{
@@ -49,10 +96,49 @@ func TestLoad(t *testing.T) {
 							"milestoned",
 						},
 						IssuesAssignerProjectConfig: IssuesAssignerProjectConfig{
-							ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1",
-							Column:     "To Do",
+							ProjectConfig: ProjectConfig{
+								ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1",
+								Column:     "To Do",
+							},
+							Columns: map[string]string{
+								"closed":              "Done",
+								"reopened":            "To Do",
+								"linked-pull-request": "In progress",
+							},
+							Targets: []ProjectTarget{
+								{
+									ProjectConfig: ProjectConfig{Owner: "octo-org", Number: 5, Column: "Todo"},
+									Labels:        []string{"area:infra"},
+								},
+								{
+									ProjectConfig: ProjectConfig{
+										ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/2",
+										Column:     "Docs",
+									},
+									Authors: []string{"@monalisa"},
+									Title:   "^docs",
+								},
+							},
+						},
+						Owners: map[string]slices.StringSlice{
+							"area:parser": {"@alice"},
+							"area:infra":  {"@myorg/infra-team"},
 						},
 					},
+					Availability: AvailabilityConfig{
+						File: ".github/availability.yml",
+						Unavailable: map[string][]DateRange{
+							"octocat": {{From: "2020-12-20", To: "2021-01-05"}},
+							"hubot":   {{From: "2020-11-01"}},
+						},
+					},
+					Milestone: MilestoneConfig{
+						Strategy:     BaseBranchMilestone,
+						BranchPrefix: "release/",
+						Create:       true,
+						Actions:      []string{"opened", "closed"},
+						Target:       PullRequestsTarget,
+					},
 				},
 				LabelerConfig: LabelerConfig{
 					IssuesLabelerConfig: IssuesLabelerConfig{
@@ -65,13 +151,28 @@ func TestLoad(t *testing.T) {
 							"opened",
 							"milestoned",
 						},
-						OneOfaKind: OneOfaKind{
-							PossibleLabels: []string{
-								"priority:1",
-								"priority:2",
-								"priority:3",
+						AtLeastOne: OneOfaKindGroups{
+							{
+								PossibleLabels: []string{
+									"priority:1",
+									"priority:2",
+									"priority:3",
+								},
+								Default: "priority:2",
+							},
+						},
+						RegexLabels: []RegexLabel{
+							{Label: "crash", Pattern: "crash|panic", IgnoreCase: true},
+						},
+						TemplateLabels: []TemplateLabel{
+							{
+								Section: "Component",
+								Values:  map[string]string{"Parser": "area:parser", "Infra": "area:infra"},
+							},
+							{
+								Checkbox: "This is a regression",
+								Label:    "regression",
 							},
-							Default: "priority:2",
 						},
 					},
 					PullRequestsLabelerConfig: PullRequestsLabelerConfig{
@@ -83,6 +184,100 @@ func TestLoad(t *testing.T) {
 							"opened",
 							"synchronize",
 						},
+						AtLeastOne: OneOfaKindGroups{
+							{
+								PossibleLabels: []string{"type:bug", "type:feature"},
+								Default:        "type:feature",
+							},
+							{
+								PossibleLabels: []string{"release-note:yes", "release-note:no"},
+								Default:        "release-note:yes",
+							},
+						},
+						Files: map[string]slices.StringSlice{
+							"area:docs":   {"docs/**", "*.md"},
+							"area:github": {"pkg/github/**"},
+						},
+						RegexLabels: []RegexLabel{
+							{Label: "needs-description", Pattern: ".+", Fields: []string{"body"}, Negate: true},
+						},
+						ConventionalCommits: ConventionalCommits{
+							Types:    map[string]string{"feat": "enhancement", "fix": "bug"},
+							Scopes:   map[string]string{"api": "area:api"},
+							Breaking: "breaking-change",
+							Status: ConventionalCommitsStatus{
+								Enabled: true,
+								Context: "pr-title",
+							},
+						},
+						Size: SizeLabels{
+							XS:     10,
+							S:      30,
+							M:      100,
+							L:      500,
+							Ignore: []string{"vendor/**"},
+						},
+						BranchLabels: []BranchLabel{
+							{Label: "backport", Base: "release/*"},
+							{Label: "hotfix", Head: `^hotfix-\d+$`, Regex: true},
+						},
+						LinkedIssues: LinkedIssuesLabels{
+							Labels: []string{"area:*", "priority:*"},
+						},
+						Reviews: ReviewLabels{
+							NeedsReview:       "needs-review",
+							ChangesRequested:  "changes-requested",
+							Approved:          "approved",
+							RequiredApprovals: 2,
+						},
+						CI: CILabels{
+							CIStateLabels: CIStateLabels{
+								Passing: "ci:passing",
+								Failing: "ci:failing",
+								Pending: "ci:pending",
+							},
+							Checks: map[string]CIStateLabels{
+								"lint":    {Failing: "lint:failing"},
+								"codecov": {},
+							},
+						},
+					},
+					RemovalRules: []RemovalRule{
+						{
+							Labels:  []string{"needs-triage"},
+							Actions: []string{"assigned"},
+							Target:  IssuesTarget,
+						},
+						{
+							Labels:  []string{"work-in-progress"},
+							Actions: []string{"ready_for_review"},
+						},
+					},
+					ExclusiveGroups: []ExclusiveGroup{
+						{
+							Labels:     []string{"priority:1", "priority:2", "priority:3"},
+							Default:    "priority:2",
+							Resolution: PrecedenceResolution,
+							Target:     IssuesTarget,
+						},
+						{
+							Labels: []string{"type:bug", "type:feature"},
+						},
+					},
+					AuthorLabels: []AuthorLabel{
+						{
+							Label:        "community",
+							Associations: []string{"FIRST_TIME_CONTRIBUTOR", "CONTRIBUTOR"},
+						},
+						{
+							Label:  "dependencies",
+							Bot:    true,
+							Target: PullRequestsTarget,
+						},
+						{
+							Label:       "external",
+							NotMemberOf: "myorg",
+						},
 					},
 				},
 			},
@@ -119,6 +314,48 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestOneOfaKindGroups_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected OneOfaKindGroups
+		wantErr  bool
+	}{
+		{
+			name: "should unmarshal a single group",
+			raw:  "labels: [bug]\ndefault: bug",
+			expected: OneOfaKindGroups{
+				{PossibleLabels: []string{"bug"}, Default: "bug"},
+			},
+		},
+		{
+			name: "should unmarshal a list of groups",
+			raw:  "- labels: [bug]\n  default: bug\n- labels: [release-note:yes]\n  default: release-note:yes",
+			expected: OneOfaKindGroups{
+				{PossibleLabels: []string{"bug"}, Default: "bug"},
+				{PossibleLabels: []string{"release-note:yes"}, Default: "release-note:yes"},
+			},
+		},
+		{
+			name:    "should error if the value is neither a group nor a list of groups",
+			raw:     "bug",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual OneOfaKindGroups
+			err := yaml.Unmarshal([]byte(tt.raw), &actual)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s error = %v, wantErr %v", t.Name(), err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func getContents(filename string) *[]byte {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -132,3 +369,91 @@ func getContents(filename string) *[]byte {
 
 	return &contents
 }
+
+func TestCILabels_For(t *testing.T) {
+	cfg := CILabels{
+		CIStateLabels: CIStateLabels{Passing: "ci:passing", Failing: "ci:failing"},
+		Checks: map[string]CIStateLabels{
+			"lint":    {Failing: "lint:failing"},
+			"codecov": {},
+		},
+	}
+	tests := []struct {
+		check    string
+		expected CIStateLabels
+	}{
+		{check: "build", expected: cfg.CIStateLabels},
+		{check: "lint", expected: CIStateLabels{Failing: "lint:failing"}},
+		{check: "codecov", expected: CIStateLabels{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.check, func(t *testing.T) {
+			actual := cfg.For(tt.check)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDateRange_Includes(t *testing.T) {
+	day := time.Date(2020, 12, 24, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		dateRange DateRange
+		expected  bool
+		wantErr   bool
+	}{
+		{name: "should include the first day", dateRange: DateRange{From: "2020-12-24", To: "2021-01-05"}, expected: true},
+		{name: "should include the last day", dateRange: DateRange{From: "2020-12-20", To: "2020-12-24"}, expected: true},
+		{name: "should include a single day range", dateRange: DateRange{From: "2020-12-24"}, expected: true},
+		{name: "should not include a day after the range", dateRange: DateRange{From: "2020-12-20", To: "2020-12-23"}},
+		{name: "should not include a day before the range", dateRange: DateRange{From: "2020-12-25"}},
+		{name: "should error if a date cannot be parsed", dateRange: DateRange{From: "2020-12-20", To: "tomorrow"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.dateRange.Includes(day)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expect error: %t Got: %v", tt.wantErr, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProjectConfig_IsEnabled(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          ProjectConfig
+		expectedV2      bool
+		expectedEnabled bool
+	}{
+		{
+			name: "should be disabled without a url or a number",
+		},
+		{
+			name:            "should be enabled for a classic project",
+			config:          ProjectConfig{ProjectURL: "https://github.com/ppapapetrou76/virtual-assistant/projects/1"},
+			expectedEnabled: true,
+		},
+		{
+			name:            "should be enabled for a project (v2)",
+			config:          ProjectConfig{Owner: "octo-org", Number: 5},
+			expectedV2:      true,
+			expectedEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.config.IsV2(); actual != tt.expectedV2 {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedV2, actual)
+			}
+			if actual := tt.config.IsEnabled(); actual != tt.expectedEnabled {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedEnabled, actual)
+			}
+		})
+	}
+}
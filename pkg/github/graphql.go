@@ -0,0 +1,45 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// graphQLResponse is the struct to represent the response of a GraphQL call. GitHub reports query errors with a
+// successful status code so they have to be checked separately
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQL runs the given GraphQL query (or mutation) with the given variables and decodes its data into the given
+// result, if it's not nil
+func (r Repo) graphQL(query string, variables map[string]interface{}, result interface{}) error {
+	req, err := r.GHClient.NewRequest("POST", "graphql", map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp := &graphQLResponse{}
+	if _, err := r.GHClient.Do(context.Background(), req, resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			messages = append(messages, e.Message)
+		}
+		return errors.New(strings.Join(messages, ", "))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, result)
+}
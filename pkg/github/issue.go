@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v27/github"
 
@@ -46,6 +49,97 @@ func (i Issue) AtLeastOne(labels slices.StringSlice, defaultLabel string) error
 	return err
 }
 
+// ExactlyOne makes sure that exactly one label of the given group is assigned to the issue/pull request. If more than
+// one is assigned it keeps the label picked by the given preferred function (if any) among the assigned ones, or the
+// first assigned label of the group if it picks none, and removes the others. If none is assigned it adds the default
+// label (if any)
+func (i Issue) ExactlyOne(labels slices.StringSlice, defaultLabel string,
+	preferred func(assigned slices.StringSlice) (string, error)) error {
+	if labels.IsEmpty() {
+		return nil
+	}
+
+	currentLabels, err := i.CurrentLabels()
+	if err != nil {
+		return err
+	}
+
+	var assigned slices.StringSlice
+	for _, label := range labels {
+		if currentLabels.HasString(label) {
+			assigned = assigned.Add(label)
+		}
+	}
+
+	switch {
+	case len(assigned) == 1:
+		return nil
+	case assigned.IsEmpty():
+		if defaultLabel == "" {
+			return nil
+		}
+		return i.ReplaceLabels(currentLabels.Add(defaultLabel))
+	}
+
+	keep := assigned[0]
+	if preferred != nil {
+		label, err := preferred(assigned)
+		if err != nil {
+			return err
+		}
+		if assigned.HasString(label) {
+			keep = label
+		}
+	}
+	return i.ReplaceLabels(currentLabels.Remove(assigned.Remove(keep)...))
+}
+
+// LatestLabel returns the label among the given ones that was most recently added to the issue/pull request. Pass only
+// the currently assigned labels to ignore the ones that were removed afterwards. It returns an empty string if none of
+// them has ever been added
+func (i Issue) LatestLabel(labels slices.StringSlice) (string, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var latest string
+	for {
+		events, resp, err := i.GHClient.Issues.ListIssueEvents(context.Background(), i.Owner, i.Name, i.Number, opts)
+		if err != nil {
+			return "", fmt.Errorf("cannot list events of issue %d. error message : %s", i.Number, err.Error())
+		}
+		// events are returned in chronological order so the last matching one wins
+		for _, e := range events {
+			if e.GetEvent() == "labeled" && labels.HasString(e.GetLabel().GetName()) {
+				latest = e.GetLabel().GetName()
+			}
+		}
+		if resp.NextPage == 0 {
+			return latest, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// RemoveLabels removes the given labels from the issue/pull request. If none of them is currently assigned it does
+// nothing
+func (i Issue) RemoveLabels(labels ...string) error {
+	currentLabels, err := i.CurrentLabels()
+	if err != nil {
+		return err
+	}
+	if !currentLabels.ContainsAny(labels...) {
+		return nil
+	}
+	return i.ReplaceLabels(currentLabels.Remove(labels...))
+}
+
+// Get returns the issue/pull request as the github api returns it
+func (i Issue) Get() (*github.Issue, error) {
+	issue, _, err := i.GHClient.Issues.Get(context.Background(), i.Owner, i.Name, i.Number)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get issue with number %d. error message : %s", i.Number, err.Error())
+	}
+	return issue, nil
+}
+
 // CurrentLabels returns the current labels of an issue/pull request
 func (i Issue) CurrentLabels() (slices.StringSlice, error) {
 	opts := github.ListOptions{}
@@ -59,6 +153,131 @@ func (i Issue) CurrentLabels() (slices.StringSlice, error) {
 	return labels, err
 }
 
+// Files returns all the files changed by a pull request along with their additions and deletions. It follows the
+// pagination links so that large pull requests return all of their files
+func (i Issue) Files() ([]*github.CommitFile, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var files []*github.CommitFile
+	for {
+		commitFiles, resp, err := i.GHClient.PullRequests.ListFiles(
+			context.Background(), i.Owner, i.Name, i.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list files of pull request %d. error message : %s", i.Number, err.Error())
+		}
+		files = append(files, commitFiles...)
+		if resp.NextPage == 0 {
+			return files, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Reviews returns all the reviews of a pull request in chronological order
+func (i Issue) Reviews() ([]*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var reviews []*github.PullRequestReview
+	for {
+		page, resp, err := i.GHClient.PullRequests.ListReviews(context.Background(), i.Owner, i.Name, i.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list reviews of pull request %d. error message : %s", i.Number, err.Error())
+		}
+		reviews = append(reviews, page...)
+		if resp.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Mergeable returns whether the pull request can be merged without conflicts. GitHub computes it lazily in a background
+// job, which is started by this call, so it returns nil while it's still unknown
+func (i Issue) Mergeable() (*bool, error) {
+	pr, _, err := i.GHClient.PullRequests.Get(context.Background(), i.Owner, i.Name, i.Number)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pull request %d. error message : %s", i.Number, err.Error())
+	}
+	return pr.Mergeable, nil
+}
+
+// Comments returns all the comments of the issue/pull request
+func (i Issue) Comments() ([]*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var comments []*github.IssueComment
+	for {
+		page, resp, err := i.GHClient.Issues.ListComments(context.Background(), i.Owner, i.Name, i.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list comments of issue %d. error message : %s", i.Number, err.Error())
+		}
+		comments = append(comments, page...)
+		if resp.NextPage == 0 {
+			return comments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// AddComment adds a comment with the given body to the issue/pull request
+func (i Issue) AddComment(body string) error {
+	log.Printf("Commenting on %s/%s#%d", i.Owner, i.Name, i.Number)
+	_, _, err := i.GHClient.Issues.CreateComment(context.Background(), i.Owner, i.Name, i.Number, &github.IssueComment{
+		Body: &body,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot comment on issue %d. error message : %s", i.Number, err.Error())
+	}
+	return nil
+}
+
+// DeleteComment deletes the comment with the given id from the issue/pull request
+func (i Issue) DeleteComment(id int64) error {
+	log.Printf("Deleting comment %d from %s/%s#%d", id, i.Owner, i.Name, i.Number)
+	_, err := i.GHClient.Issues.DeleteComment(context.Background(), i.Owner, i.Name, id)
+	if err != nil {
+		return fmt.Errorf("cannot delete comment %d of issue %d. error message : %s", id, i.Number, err.Error())
+	}
+	return nil
+}
+
+// AddReaction adds a reaction (e.g. `+1` or `confused`) to the issue comment with the given id
+func (i Issue) AddReaction(commentID int64, content string) error {
+	_, _, err := i.GHClient.Reactions.CreateIssueCommentReaction(context.Background(), i.Owner, i.Name, commentID, content)
+	if err != nil {
+		return fmt.Errorf("cannot react to comment %d of issue %d. error message : %s", commentID, i.Number, err.Error())
+	}
+	return nil
+}
+
+// AddAssignees adds the given users as assignees of the issue/pull request
+func (i Issue) AddAssignees(users ...string) error {
+	log.Printf("Assigning %s/%s#%d to %s", i.Owner, i.Name, i.Number, users)
+	_, _, err := i.GHClient.Issues.AddAssignees(context.Background(), i.Owner, i.Name, i.Number, users)
+	if err != nil {
+		return fmt.Errorf("cannot assign issue %d to %s. error message : %s", i.Number, strings.Join(users, ", "), err.Error())
+	}
+	return nil
+}
+
+// RequestReviewers requests reviews of the pull request from the given users and teams
+func (i Issue) RequestReviewers(users, teams []string) error {
+	log.Printf("Requesting reviews of %s/%s#%d from %s %s", i.Owner, i.Name, i.Number, users, teams)
+	_, _, err := i.GHClient.PullRequests.RequestReviewers(context.Background(), i.Owner, i.Name, i.Number,
+		github.ReviewersRequest{Reviewers: users, TeamReviewers: teams})
+	if err != nil {
+		return fmt.Errorf("cannot request reviewers of pull request %d. error message : %s", i.Number, err.Error())
+	}
+	return nil
+}
+
+// SetMilestone sets the milestone with the given number to the issue/PR
+func (i Issue) SetMilestone(milestone int) error {
+	log.Printf("Setting milestone %d to %s/%s#%d", milestone, i.Owner, i.Name, i.Number)
+	_, _, err := i.GHClient.Issues.Edit(context.Background(), i.Owner, i.Name, i.Number, &github.IssueRequest{Milestone: &milestone})
+	if err != nil {
+		return fmt.Errorf("cannot set milestone of issue (%d). error message : %s", i.Number, err.Error())
+	}
+	return nil
+}
+
 // AddAssignee adds the user who created the issue/PR as assignee
 func (i Issue) AddAssignee() error {
 	log.Printf("Assigning the PR/Issue to the user who created it")
@@ -70,40 +289,165 @@ func (i Issue) AddAssignee() error {
 	return err
 }
 
-// AddToProject adds the issue to the given project. If the project doesn't exist it returns an error
+// AddToProject adds the issue to the given column of the given project. If the issue is already in the project it does
+// nothing and if the project or the column doesn't exist it returns an error
 func (i Issue) AddToProject(projectURL, column string) error {
-	log.Printf("Adding to project %s in column %s", projectURL, column)
+	content, err := i.issueContent()
+	if err != nil {
+		return err
+	}
+	return i.placeInProject(projectURL, column, content, false)
+}
+
+// MoveToColumn moves the card of the issue to the given column of the given project. If the issue is not in the project
+// yet it's added to the column
+func (i Issue) MoveToColumn(projectURL, column string) error {
+	content, err := i.issueContent()
+	if err != nil {
+		return err
+	}
+	return i.placeInProject(projectURL, column, content, true)
+}
+
+// AddPullRequestToProject adds the pull request to the given column of the given project. If the pull request is
+// already in the project it does nothing and if the project or the column doesn't exist it returns an error
+func (i Issue) AddPullRequestToProject(projectURL, column string) error {
+	content, err := i.pullRequestContent()
+	if err != nil {
+		return err
+	}
+	return i.placeInProject(projectURL, column, content, false)
+}
+
+// projectContent is the struct to represent the content of a project card, i.e. an issue or a pull request
+type projectContent struct {
+	// Type is the content type of the card, `Issue` or `PullRequest`
+	Type string
+	ID   int64
+	// NodeID is the GraphQL id of the content, used by the projects (v2)
+	NodeID string
+	// URL is the api url of the issue, which is the content url of the cards of both issues and pull requests
+	URL string
+}
+
+func (i Issue) issueContent() (projectContent, error) {
 	issue, _, err := i.GHClient.Issues.Get(context.Background(), i.Owner, i.Name, i.Number)
 	if err != nil {
-		return fmt.Errorf("cannot get issue with number %d. error message : %s", i.Number, err.Error())
+		return projectContent{}, fmt.Errorf("cannot get issue with number %d. error message : %s", i.Number, err.Error())
 	}
+	return projectContent{Type: "Issue", ID: issue.GetID(), NodeID: issue.GetNodeID(), URL: issue.GetURL()}, nil
+}
 
+func (i Issue) pullRequestContent() (projectContent, error) {
+	pr, _, err := i.GHClient.PullRequests.Get(context.Background(), i.Owner, i.Name, i.Number)
+	if err != nil {
+		return projectContent{}, fmt.Errorf("cannot get pull request with number %d. error message : %s", i.Number, err.Error())
+	}
+	return projectContent{Type: "PullRequest", ID: pr.GetID(), NodeID: pr.GetNodeID(), URL: pr.GetIssueURL()}, nil
+}
+
+func (i Issue) placeInProject(projectURL, column string, content projectContent, move bool) error {
+	log.Printf("Adding to project %s in column %s", projectURL, column)
 	projectID, err := i.Repo.GetProjectID(projectURL)
 	if err != nil {
 		return err
 	}
-	opts := &github.ProjectCardOptions{
-		ContentType: "Issue",
-		ContentID:   *issue.ID,
-	}
 	columns, _, err := i.GHClient.Projects.ListProjectColumns(context.Background(), projectID, &github.ListOptions{})
 	if err != nil {
 		return fmt.Errorf("cannot get project (%d) columns. error message : %s", projectID, err.Error())
 	}
 
+	var target *github.ProjectColumn
 	for _, c := range columns {
 		if *c.Name == column {
-			_, _, err := i.GHClient.Projects.CreateProjectCard(context.Background(), *c.ID, opts)
+			target = c
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("cannot add issue (%d) to project (%d). error message : no project column found with name %s",
+			i.Number, projectID, column)
+	}
+
+	card, current, err := i.findProjectCard(columns, content.URL)
+	if err != nil {
+		return err
+	}
+	switch {
+	case card == nil:
+		opts := &github.ProjectCardOptions{
+			ContentType: content.Type,
+			ContentID:   content.ID,
+		}
+		if _, _, err := i.GHClient.Projects.CreateProjectCard(context.Background(), *target.ID, opts); err != nil {
+			return fmt.Errorf("cannot add issue (%d) to project (%d). error message : %s",
+				i.Number, projectID, err.Error())
+		}
+	case move && current.GetID() != target.GetID():
+		log.Printf("Moving card of %s/%s#%d from column %s to %s", i.Owner, i.Name, i.Number, current.GetName(), column)
+		opts := &github.ProjectCardMoveOptions{Position: "top", ColumnID: target.GetID()}
+		if _, err := i.GHClient.Projects.MoveProjectCard(context.Background(), card.GetID(), opts); err != nil {
+			return fmt.Errorf("cannot move issue (%d) to column %s of project (%d). error message : %s",
+				i.Number, column, projectID, err.Error())
+		}
+	default:
+		log.Printf("%s/%s#%d is already in column %s", i.Owner, i.Name, i.Number, current.GetName())
+	}
+	return nil
+}
+
+// findProjectCard returns the card of the given content url (i.e. the api url of an issue or a pull request) and its
+// column among the given project columns. It returns nil if there is no such card
+func (i Issue) findProjectCard(columns []*github.ProjectColumn, contentURL string) (*github.ProjectCard, *github.ProjectColumn, error) {
+	for _, c := range columns {
+		opts := &github.ProjectCardListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for {
+			cards, resp, err := i.GHClient.Projects.ListProjectCards(context.Background(), c.GetID(), opts)
 			if err != nil {
-				return fmt.Errorf("cannot add issue (%d) to project (%d). error message : %s",
-					i.Number, projectID, err.Error())
+				return nil, nil, fmt.Errorf("cannot get cards of project column %s. error message : %s", c.GetName(), err.Error())
 			}
-			return nil
+			for _, card := range cards {
+				if card.GetContentURL() == contentURL {
+					return card, c, nil
+				}
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
 		}
 	}
+	return nil, nil, nil
+}
+
+var linkedIssueRegexp = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+` +
+	`(?:https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)|(?:([\w.-]+)/([\w.-]+))?#(\d+))\b`)
 
-	return fmt.Errorf("cannot add issue (%d) to project (%d). error message : no project column found with name %s",
-		i.Number, projectID, column)
+// LinkedIssues returns the issues that the given text (usually a pull request body) references with a closing keyword,
+// e.g. `Fixes #123`, `Closes org/repo#45` or `Resolves https://github.com/org/repo/issues/67`. Each issue is returned
+// once, in the order it's referenced, and issues of other repositories share the github client of this issue
+func (i Issue) LinkedIssues(text string) []Issue {
+	var issues []Issue
+	seen := map[string]bool{}
+	for _, matches := range linkedIssueRegexp.FindAllStringSubmatch(text, -1) {
+		owner, name, number := i.Owner, i.Name, matches[6]
+		switch {
+		case matches[1] != "":
+			owner, name, number = matches[1], matches[2], matches[3]
+		case matches[4] != "":
+			owner, name = matches[4], matches[5]
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			continue
+		}
+		key := strings.ToLower(fmt.Sprintf("%s/%s#%d", owner, name, n))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		issues = append(issues, NewIssue(Repo{Owner: owner, Name: name, GHClient: i.GHClient}, n))
+	}
+	return issues
 }
 
 // NewIssue returns a new Issue struct
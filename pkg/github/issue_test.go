@@ -2,6 +2,7 @@ package github
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
@@ -11,6 +12,72 @@ import (
 	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
 )
 
+func TestIssue_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		wantErr       bool
+		expectedError error
+		expectedTitle string
+	}{
+		{
+			name:          "should return the issue",
+			responses:     []MockResponse{MockGetIssueResponse()},
+			expectedTitle: "Found a bug",
+		},
+		{
+			name:          "should error if the issue cannot be fetched",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get issue with number 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347).Get()
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			if !tt.wantErr && issue.GetTitle() != tt.expectedTitle {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedTitle, issue.GetTitle())
+			}
+		})
+	}
+}
+
+func TestIssue_SetMilestone(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should set the milestone",
+			responses: []MockResponse{MockGetIssueResponse()},
+		},
+		{
+			name:          "should error if the milestone cannot be set",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot set milestone of issue (1347). error message : PATCH https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347).SetMilestone(2)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
 func TestIssue_CurrentLabels(t *testing.T) {
 	type fields struct {
 		ghClient ClientWrapper
@@ -347,6 +414,8 @@ func TestIssue_AddToProject(t *testing.T) {
 					MockGetIssueResponse(),
 					MockListRepositoryProjectsResponse(),
 					MockListProjectColumnsResponse(),
+					MockListEmptyProjectCardsResponse(),
+					MockListEmptyProjectCardsResponse(),
 					UnAuthorizedMockResponse(),
 				}),
 			},
@@ -365,6 +434,8 @@ func TestIssue_AddToProject(t *testing.T) {
 					MockGetIssueResponse(),
 					MockListRepositoryProjectsResponse(),
 					MockListProjectColumnsResponse(),
+					MockListEmptyProjectCardsResponse(),
+					MockListEmptyProjectCardsResponse(),
 					MockGenericSuccessResponse(),
 				}),
 			},
@@ -382,6 +453,8 @@ func TestIssue_AddToProject(t *testing.T) {
 					MockListRepositoryProjectsResponse(),
 					MockListOrganizationProjectsResponse(),
 					MockListProjectColumnsResponse(),
+					MockListEmptyProjectCardsResponse(),
+					MockListEmptyProjectCardsResponse(),
 					MockGenericSuccessResponse(),
 				}),
 			},
@@ -416,6 +489,8 @@ func TestIssue_AddToProject(t *testing.T) {
 					MockListEmptyProjectsResponse(),
 					MockListEmptyProjectsResponse(),
 					MockListProjectColumnsResponse(),
+					MockListEmptyProjectCardsResponse(),
+					MockListEmptyProjectCardsResponse(),
 					MockGenericSuccessResponse(),
 				}),
 			},
@@ -500,3 +575,707 @@ func TestIssue_AddAssignee(t *testing.T) {
 		})
 	}
 }
+
+func TestIssue_Files(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		wantErr       bool
+		expectedError error
+		expectedFiles []string
+	}{
+		{
+			name: "should return the pull request files",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListPullRequestFilesResponse(),
+				}),
+			},
+			expectedFiles: []string{"docs/README.md", "pkg/github/issue.go"},
+		},
+		{
+			name: "should return the pull request files of all pages",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockNextPage(MockListPullRequestFilesResponse(), 2),
+					MockListPullRequestFilesResponse(),
+				}),
+			},
+			expectedFiles: []string{"docs/README.md", "pkg/github/issue.go", "docs/README.md", "pkg/github/issue.go"},
+		},
+		{
+			name: "should error if files cannot be listed",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("cannot list files of pull request 0. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/0/files?per_page=100: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+
+			pr := Issue{
+				Repo:   repo,
+				Number: 0,
+			}
+			files, err := pr.Files()
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			var actualFiles []string
+			for _, f := range files {
+				actualFiles = append(actualFiles, f.GetFilename())
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(actualFiles, tt.expectedFiles) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedFiles, actualFiles)
+			}
+		})
+	}
+}
+
+func TestIssue_RemoveLabels(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	type args struct {
+		labels []string
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should do nothing if none of the labels is assigned",
+			args: args{labels: []string{"needs-triage"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+				}),
+			},
+		},
+		{
+			name: "should remove the assigned labels",
+			args: args{labels: []string{"bug", "needs-triage"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+					MockGenericSuccessResponse(),
+				}),
+			},
+		},
+		{
+			name: "should error if current labels cannot be retrieved",
+			args: args{labels: []string{"bug"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/0/labels: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+
+			pr := Issue{
+				Repo:   repo,
+				Number: 0,
+			}
+			err := pr.RemoveLabels(tt.args.labels...)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestIssue_ExactlyOne(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	type args struct {
+		labels       slices.StringSlice
+		defaultLabel string
+		preferred    func(assigned slices.StringSlice) (string, error)
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should do nothing if labels group is empty",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{}),
+			},
+		},
+		{
+			name: "should do nothing if exactly one label of the group is assigned",
+			args: args{
+				labels: []string{"bug", "question"},
+				preferred: func(slices.StringSlice) (string, error) {
+					return "", errors.New("should not look for the preferred label")
+				},
+			},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+				}),
+			},
+		},
+		{
+			name: "should do nothing if no label of the group is assigned and there is no default label",
+			args: args{
+				labels: []string{"priority:1", "priority:2"},
+			},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+				}),
+			},
+		},
+		{
+			name: "should add the default label if no label of the group is assigned",
+			args: args{
+				labels:       []string{"priority:1", "priority:2"},
+				defaultLabel: "priority:2",
+			},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+					MockGenericSuccessResponse(),
+				}),
+			},
+		},
+		{
+			name: "should keep only one label if more than one label of the group is assigned",
+			args: args{
+				labels:    []string{"bug", "enhancement"},
+				preferred: func(slices.StringSlice) (string, error) { return "enhancement", nil },
+			},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+					MockGenericSuccessResponse(),
+				}),
+			},
+		},
+		{
+			name: "should error if the preferred label cannot be picked",
+			args: args{
+				labels:    []string{"bug", "enhancement"},
+				preferred: func(slices.StringSlice) (string, error) { return "", errors.New("cannot list events") },
+			},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueLabelsResponse(),
+				}),
+			},
+			expectedError: errors.New("cannot list events"),
+			wantErr:       true,
+		},
+		{
+			name: "should error if current labels cannot be retrieved",
+			args: args{
+				labels: []string{"bug", "enhancement"},
+			},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/0/labels: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+
+			pr := Issue{
+				Repo:   repo,
+				Number: 0,
+			}
+			err := pr.ExactlyOne(tt.args.labels, tt.args.defaultLabel, tt.args.preferred)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestIssue_LatestLabel(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	type args struct {
+		labels slices.StringSlice
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantErr       bool
+		expectedError error
+		expected      string
+	}{
+		{
+			name: "should return the most recently added label",
+			args: args{labels: []string{"bug", "enhancement"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueEventsResponse(),
+				}),
+			},
+			expected: "enhancement",
+		},
+		{
+			name: "should return the most recently added label among the given ones",
+			args: args{labels: []string{"bug", "question"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueEventsResponse(),
+				}),
+			},
+			expected: "bug",
+		},
+		{
+			name: "should return an empty label if none of the given ones was added",
+			args: args{labels: []string{"question"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockListIssueEventsResponse(),
+				}),
+			},
+		},
+		{
+			name: "should error if issue events cannot be retrieved",
+			args: args{labels: []string{"bug"}},
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("cannot list events of issue 0. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/0/events?per_page=100: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+
+			pr := Issue{
+				Repo:   repo,
+				Number: 0,
+			}
+			actual, err := pr.LatestLabel(tt.args.labels)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIssue_LinkedIssues(t *testing.T) {
+	repo := Repo{
+		Owner: "ppapapetrou76",
+		Name:  "virtual-assistant",
+	}
+	tests := []struct {
+		name     string
+		text     string
+		expected []Issue
+	}{
+		{
+			name: "should return the issues referenced with closing keywords",
+			text: "Fixes #123, closes myorg/other-repo#45 and Resolves: https://github.com/myorg/other.repo/issues/67",
+			expected: []Issue{
+				NewIssue(repo, 123),
+				NewIssue(Repo{Owner: "myorg", Name: "other-repo"}, 45),
+				NewIssue(Repo{Owner: "myorg", Name: "other.repo"}, 67),
+			},
+		},
+		{
+			name: "should return each issue once",
+			text: "fix #1\nFIXED #1\nresolved ppapapetrou76/virtual-assistant#1",
+			expected: []Issue{
+				NewIssue(repo, 1),
+			},
+		},
+		{
+			name: "should ignore references without closing keywords or issue numbers",
+			text: "Related to #12, fixes 3 bugs and prefix #4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := NewIssue(repo, 99).LinkedIssues(tt.text)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIssue_Reviews(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		wantErr         bool
+		expectedError   error
+		expectedReviews int
+	}{
+		{
+			name: "should return the reviews of all pages",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockNextPage(MockListReviewsResponse(), 2),
+					MockListReviewsResponse(),
+				}),
+			},
+			expectedReviews: 8,
+		},
+		{
+			name: "should error if the reviews cannot be listed",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("cannot list reviews of pull request 0. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/0/reviews?per_page=100: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+
+			pr := Issue{
+				Repo:   repo,
+				Number: 0,
+			}
+			reviews, err := pr.Reviews()
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if len(reviews) != tt.expectedReviews {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedReviews, len(reviews))
+			}
+		})
+	}
+}
+
+func TestIssue_Mergeable(t *testing.T) {
+	conflicting := false
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		expected      *bool
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should return the mergeable state",
+			responses: []MockResponse{MockGetPullRequestResponse("false")},
+			expected:  &conflicting,
+		},
+		{
+			name:      "should return nil if the mergeable state is not computed yet",
+			responses: []MockResponse{MockGetPullRequestResponse("null")},
+		},
+		{
+			name:          "should error if the pull request cannot be retrieved",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get pull request 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347)
+			actual, err := pr.Mergeable()
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIssue_Comments(t *testing.T) {
+	tests := []struct {
+		name          string
+		call          func(i Issue) error
+		responses     []MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should list the comments of all pages",
+			call: func(i Issue) error {
+				comments, err := i.Comments()
+				if err == nil && len(comments) != 4 {
+					return fmt.Errorf("expected 4 comments got %d", len(comments))
+				}
+				return err
+			},
+			responses: []MockResponse{
+				MockNextPage(MockListIssueCommentsResponse(), 2),
+				MockListIssueCommentsResponse(),
+			},
+		},
+		{
+			name:          "should error if the comments cannot be listed",
+			call:          func(i Issue) error { _, err := i.Comments(); return err },
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot list comments of issue 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347/comments?per_page=100: 401 Bad credentials []"),
+		},
+		{
+			name:      "should add a comment",
+			call:      func(i Issue) error { return i.AddComment("Me too") },
+			responses: []MockResponse{MockGenericSuccessResponse()},
+		},
+		{
+			name:          "should error if a comment cannot be added",
+			call:          func(i Issue) error { return i.AddComment("Me too") },
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot comment on issue 1347. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347/comments: 401 Bad credentials []"),
+		},
+		{
+			name:      "should delete a comment",
+			call:      func(i Issue) error { return i.DeleteComment(2) },
+			responses: []MockResponse{MockGenericSuccessResponse()},
+		},
+		{
+			name:          "should error if a comment cannot be deleted",
+			call:          func(i Issue) error { return i.DeleteComment(2) },
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot delete comment 2 of issue 1347. error message : DELETE https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/comments/2: 401 Bad credentials []"),
+		},
+		{
+			name:      "should react to a comment",
+			call:      func(i Issue) error { return i.AddReaction(2, "+1") },
+			responses: []MockResponse{MockGenericSuccessResponse()},
+		},
+		{
+			name:          "should error if a comment cannot be reacted to",
+			call:          func(i Issue) error { return i.AddReaction(2, "+1") },
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot react to comment 2 of issue 1347. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/comments/2/reactions: 401 Bad credentials []"),
+		},
+		{
+			name:      "should request reviewers",
+			call:      func(i Issue) error { return i.RequestReviewers([]string{"octocat"}, []string{"backend"}) },
+			responses: []MockResponse{MockGenericSuccessResponse()},
+		},
+		{
+			name:          "should error if the reviewers cannot be requested",
+			call:          func(i Issue) error { return i.RequestReviewers([]string{"octocat"}, nil) },
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot request reviewers of pull request 1347. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/1347/requested_reviewers: 401 Bad credentials []"),
+		},
+		{
+			name:      "should add assignees",
+			call:      func(i Issue) error { return i.AddAssignees("octocat", "hubot") },
+			responses: []MockResponse{MockGenericSuccessResponse()},
+		},
+		{
+			name:          "should error if the assignees cannot be added",
+			call:          func(i Issue) error { return i.AddAssignees("octocat", "hubot") },
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot assign issue 1347 to octocat, hubot. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347/assignees: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347)
+			err := tt.call(issue)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestIssue_MoveToColumn(t *testing.T) {
+	tests := []struct {
+		name          string
+		call          func(i Issue) error
+		responses     []MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should not add the issue again if it's already in the project",
+			call: func(i Issue) error {
+				return i.AddToProject("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "Done")
+			},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListProjectCardsResponse(),
+			},
+		},
+		{
+			name: "should move the card to the given column",
+			call: func(i Issue) error {
+				return i.MoveToColumn("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "Done")
+			},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListProjectCardsResponse(),
+				MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should do nothing if the card is already in the given column",
+			call: func(i Issue) error {
+				return i.MoveToColumn("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "To Do")
+			},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListProjectCardsResponse(),
+			},
+		},
+		{
+			name: "should add the issue to the given column if it's not in the project",
+			call: func(i Issue) error {
+				return i.MoveToColumn("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "Done")
+			},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListEmptyProjectCardsResponse(),
+				MockListEmptyProjectCardsResponse(),
+				MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should error if the card cannot be moved",
+			call: func(i Issue) error {
+				return i.MoveToColumn("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "Done")
+			},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListProjectCardsResponse(),
+				UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot move issue (1347) to column Done of project (1002604). error message : POST https://api.github.com/projects/columns/cards/1479/moves: 401 Bad credentials []"),
+		},
+		{
+			name: "should error if the cards cannot be listed",
+			call: func(i Issue) error {
+				return i.MoveToColumn("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "Done")
+			},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get cards of project column To Do. error message : GET https://api.github.com/projects/columns/367/cards?per_page=100: 401 Bad credentials []"),
+		},
+		{
+			name: "should add the pull request to the given column if it's not in the project",
+			call: func(i Issue) error {
+				return i.AddPullRequestToProject("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "To Do")
+			},
+			responses: []MockResponse{
+				MockGetPullRequestResponse("true"),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListEmptyProjectCardsResponse(),
+				MockListEmptyProjectCardsResponse(),
+				MockGenericSuccessResponse(),
+			},
+		},
+		{
+			name: "should not add the pull request again if it's already in the project",
+			call: func(i Issue) error {
+				return i.AddPullRequestToProject("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "To Do")
+			},
+			responses: []MockResponse{
+				MockGetPullRequestResponse("true"),
+				MockListRepositoryProjectsResponse(),
+				MockListProjectColumnsResponse(),
+				MockListProjectCardsResponse(),
+			},
+		},
+		{
+			name: "should error if the pull request cannot be fetched",
+			call: func(i Issue) error {
+				return i.AddPullRequestToProject("https://github.com/ppapapetrou76/virtual-assistant/projects/1", "To Do")
+			},
+			responses: []MockResponse{
+				UnAuthorizedMockResponse(),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot get pull request with number 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347)
+			err := tt.call(issue)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
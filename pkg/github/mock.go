@@ -2,6 +2,8 @@ package github
 
 import (
 	"bytes"
+	"encoding/base64"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 )
@@ -10,6 +12,7 @@ const getIssueResponse = `{
   "id": 1,
   "node_id": "MDU6SXNzdWUx",
   "number": 1347,
+  "url": "https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347",
   "state": "open",
   "title": "Found a bug",
   "body": "I'm having a problem with this.",
@@ -29,6 +32,28 @@ const listProjectColumnsResponse = `[
     "name": "To Do",
     "created_at": "2016-09-05T14:18:44Z",
     "updated_at": "2016-09-05T14:22:28Z"
+  },
+  {
+    "url": "https://api.github.com/projects/columns/368",
+    "project_url": "https://api.github.com/projects/120",
+    "cards_url": "https://api.github.com/projects/columns/368/cards",
+    "id": 368,
+    "node_id": "MDEzOlByb2plY3RDb2x1bW4zNjg=",
+    "name": "Done",
+    "created_at": "2016-09-05T14:18:44Z",
+    "updated_at": "2016-09-05T14:22:28Z"
+  }
+]`
+
+const listProjectCardsResponse = `[
+  {
+    "id": 1478,
+    "note": "Add payload for delete Project column",
+    "content_url": ""
+  },
+  {
+    "id": 1479,
+    "content_url": "https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347"
   }
 ]`
 
@@ -80,25 +105,201 @@ const listOrganizationProjectsResponse = `[
 ]`
 const listEmptyProjectsResponse = `[]`
 
+const listPullRequestFilesResponse = `[
+  {
+    "sha": "bbcd538c8e72b8c175046e27cc8f907076331401",
+    "filename": "docs/README.md",
+    "status": "modified",
+    "additions": 103,
+    "deletions": 21,
+    "changes": 124
+  },
+  {
+    "sha": "bbcd538c8e72b8c175046e27cc8f907076331402",
+    "filename": "pkg/github/issue.go",
+    "status": "added",
+    "additions": 10,
+    "deletions": 2,
+    "changes": 12
+  }
+]`
+
+const listIssueEventsResponse = `[
+  {
+    "id": 1,
+    "event": "labeled",
+    "label": {
+      "name": "bug",
+      "color": "f29513"
+    },
+    "created_at": "2011-04-14T16:00:49Z"
+  },
+  {
+    "id": 2,
+    "event": "assigned",
+    "created_at": "2011-04-14T16:01:49Z"
+  },
+  {
+    "id": 3,
+    "event": "labeled",
+    "label": {
+      "name": "enhancement",
+      "color": "a2eeef"
+    },
+    "created_at": "2011-04-14T16:02:49Z"
+  }
+]`
+
+const listReviewsResponse = `[
+  {
+    "id": 80,
+    "user": {
+      "login": "octocat"
+    },
+    "state": "CHANGES_REQUESTED"
+  },
+  {
+    "id": 81,
+    "user": {
+      "login": "hubot"
+    },
+    "state": "APPROVED"
+  },
+  {
+    "id": 82,
+    "user": {
+      "login": "octocat"
+    },
+    "state": "COMMENTED"
+  },
+  {
+    "id": 83,
+    "user": {
+      "login": "octocat"
+    },
+    "state": "APPROVED"
+  }
+]`
+
+const listPullRequestsWithCommitResponse = `[
+  {
+    "id": 1,
+    "number": 1347,
+    "state": "open",
+    "title": "Amazing new feature",
+    "head": {
+      "ref": "new-topic",
+      "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+    }
+  },
+  {
+    "id": 2,
+    "number": 1348,
+    "state": "open",
+    "title": "Follow up of the amazing new feature",
+    "head": {
+      "ref": "new-topic-follow-up",
+      "sha": "c0ffee5b57875f334f61aebed695e2e4193db5e9"
+    }
+  },
+  {
+    "id": 3,
+    "number": 1200,
+    "state": "closed",
+    "title": "Old feature",
+    "head": {
+      "ref": "old-topic",
+      "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+    }
+  }
+]`
+
+const listIssueCommentsResponse = `[
+  {
+    "id": 1,
+    "body": "Me too",
+    "user": {
+      "login": "octocat"
+    }
+  },
+  {
+    "id": 2,
+    "body": "This pull request has conflicts\n\n<!-- virtual-assistant:merge-conflict -->",
+    "user": {
+      "login": "github-actions[bot]"
+    }
+  }
+]`
+
+const listPullRequestsResponse = `[
+  {
+    "id": 1,
+    "number": 1347,
+    "state": "open",
+    "requested_reviewers": [
+      {
+        "login": "octocat"
+      },
+      {
+        "login": "hubot"
+      }
+    ],
+    "requested_teams": [
+      {
+        "slug": "backend"
+      }
+    ]
+  },
+  {
+    "id": 2,
+    "number": 1348,
+    "state": "open",
+    "requested_reviewers": [
+      {
+        "login": "octocat"
+      }
+    ]
+  }
+]`
+
+const listTeamMembersResponse = `[
+  {
+    "login": "octocat",
+    "id": 1
+  },
+  {
+    "login": "monalisa",
+    "id": 2
+  }
+]`
+
 // MockResponse mocks an http response
 type MockResponse struct {
 	StatusCode int
 	Response   string
+	Header     http.Header
 }
 
 // MockRoundTripper mocks a RoundTripper
 type MockRoundTripper struct {
-	Responses         []MockResponse
+	Responses []MockResponse
+	// Requests holds the requests made so far, in order, so that tests can check their URLs
+	Requests          []*http.Request
 	nextResponseIndex int
 }
 
 // RoundTrip implements the RoundTripper interface
 func (m *MockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.Requests = append(m.Requests, req)
 	r := m.nextResponse()
+	header := r.Header
+	if header == nil {
+		header = make(http.Header)
+	}
 	return &http.Response{
 		StatusCode: r.StatusCode,
 		Body:       ioutil.NopCloser(bytes.NewBufferString(r.Response)),
-		Header:     make(http.Header),
+		Header:     header,
 		Request:    req,
 	}, nil
 }
@@ -192,3 +393,306 @@ func MockGenericSuccessResponse() MockResponse {
 		StatusCode: http.StatusOK,
 	}
 }
+
+// MockListPullRequestFilesResponse returns a mock response for the list pull request files call
+func MockListPullRequestFilesResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listPullRequestFilesResponse,
+	}
+}
+
+// MockNextPage returns a copy of the given mock response with a link header pointing to the given next page
+func MockNextPage(r MockResponse, page int) MockResponse {
+	r.Header = http.Header{}
+	r.Header.Set("Link", fmt.Sprintf(`<https://api.github.com/resource?page=%d>; rel="next"`, page))
+	return r
+}
+
+// MockListIssueEventsResponse returns a mock response for the list issue events call
+func MockListIssueEventsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listIssueEventsResponse,
+	}
+}
+
+// MockListRepositoryLabelsResponse returns a mock response for the list repository labels call
+func MockListRepositoryLabelsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listIssueLabelsResponse,
+	}
+}
+
+// MockListReviewsResponse returns a mock response for the list pull request reviews call
+func MockListReviewsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listReviewsResponse,
+	}
+}
+
+// MockListPullRequestsWithCommitResponse returns a mock response for the list pull requests of a commit call
+func MockListPullRequestsWithCommitResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listPullRequestsWithCommitResponse,
+	}
+}
+
+const getPullRequestResponse = `{
+  "id": 1,
+  "node_id": "PR_kwDOAQ",
+  "number": 1347,
+  "state": "open",
+  "issue_url": "https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347",
+  "mergeable": %s
+}`
+
+// MockGetPullRequestResponse returns a mock response for the get pull request call with the given mergeable state
+// (`true`, `false` or `null` if it's not computed yet)
+func MockGetPullRequestResponse(mergeable string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   fmt.Sprintf(getPullRequestResponse, mergeable),
+	}
+}
+
+// MockListIssueCommentsResponse returns a mock response for the list issue comments call
+func MockListIssueCommentsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listIssueCommentsResponse,
+	}
+}
+
+// MockGetPermissionLevelResponse returns a mock response for the get permission level call with the given legacy
+// permission and role name
+func MockGetPermissionLevelResponse(permission, role string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response: fmt.Sprintf(`{"permission": "%s", "role_name": "%s", "user": {"login": "octocat"}}`,
+			permission, role),
+	}
+}
+
+// MockListPullRequestsResponse returns a mock response for the list pull requests call
+func MockListPullRequestsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listPullRequestsResponse,
+	}
+}
+
+// MockNotFoundResponse returns a mock response with a 404 error code and message
+func MockNotFoundResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusNotFound,
+		Response:   `{"message": "Not Found", "documentation_url": "https://developer.github.com/v3"}`,
+	}
+}
+
+// MockGetContentsResponse returns a mock response for the get contents call of a file with the given content
+func MockGetContentsResponse(content string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response: fmt.Sprintf(`{"type": "file", "encoding": "base64", "content": "%s"}`,
+			base64.StdEncoding.EncodeToString([]byte(content))),
+	}
+}
+
+// MockGetTeamResponse returns a mock response for the get team by slug call
+func MockGetTeamResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   `{"id": 42, "slug": "backend", "name": "Backend"}`,
+	}
+}
+
+// MockListTeamMembersResponse returns a mock response for the list team members call
+func MockListTeamMembersResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listTeamMembersResponse,
+	}
+}
+
+// MockListProjectCardsResponse returns a mock response for the list project cards call, with a note card and a card
+// of the issue of MockGetIssueResponse
+func MockListProjectCardsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listProjectCardsResponse,
+	}
+}
+
+// MockListEmptyProjectCardsResponse returns a mock response for the list project cards call of an empty column
+func MockListEmptyProjectCardsResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   `[]`,
+	}
+}
+
+const getProjectV2Response = `{
+  "data": {
+    "repositoryOwner": {
+      "projectV2": {
+        "id": "PVT_kwDOAQ",
+        "fields": {
+          "nodes": [
+            {
+              "id": "PVTF_title",
+              "name": "Title",
+              "dataType": "TITLE"
+            },
+            {
+              "id": "PVTSSF_status",
+              "name": "Status",
+              "dataType": "SINGLE_SELECT",
+              "options": [
+                {"id": "f75ad846", "name": "Todo"},
+                {"id": "47fc9ee4", "name": "In Progress"},
+                {"id": "98236657", "name": "Done"}
+              ]
+            },
+            {
+              "id": "PVTSSF_priority",
+              "name": "Priority",
+              "dataType": "SINGLE_SELECT",
+              "options": [
+                {"id": "79628723", "name": "High"},
+                {"id": "0a877460", "name": "Low"}
+              ]
+            },
+            {
+              "id": "PVTIF_iteration",
+              "name": "Iteration",
+              "dataType": "ITERATION",
+              "configuration": {
+                "iterations": [
+                  {"id": "cfc16e4d", "title": "Sprint 12", "startDate": "2020-12-14", "duration": 14},
+                  {"id": "4f9a0e3a", "title": "Sprint 13", "startDate": "2020-12-28", "duration": 14}
+                ]
+              }
+            },
+            {
+              "id": "PVTF_estimate",
+              "name": "Estimate",
+              "dataType": "NUMBER"
+            },
+            {
+              "id": "PVTF_team",
+              "name": "Team",
+              "dataType": "TEXT"
+            }
+          ]
+        }
+      }
+    }
+  }
+}`
+
+// MockGetProjectV2Response returns a mock response for the get project (v2) GraphQL query, with the Status, Priority
+// and Iteration single select / iteration fields and the Estimate and Team number / text fields
+func MockGetProjectV2Response() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   getProjectV2Response,
+	}
+}
+
+// MockAddProjectV2ItemResponse returns a mock response for the add project (v2) item GraphQL mutation
+func MockAddProjectV2ItemResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   `{"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_lADOAQ"}}}}`,
+	}
+}
+
+// MockUpdateProjectV2ItemFieldResponse returns a mock response for the update project (v2) item field value GraphQL
+// mutation
+func MockUpdateProjectV2ItemFieldResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   `{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_lADOAQ"}}}}`,
+	}
+}
+
+// MockGraphQLErrorResponse returns a mock response of a GraphQL call that failed with the given message
+func MockGraphQLErrorResponse(message string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   fmt.Sprintf(`{"data": null, "errors": [{"message": %q}]}`, message),
+	}
+}
+
+const listMilestonesResponse = `[
+  {
+    "id": 1002604,
+    "number": 1,
+    "title": "1.3",
+    "state": "open",
+    "due_on": "2020-12-01T08:00:00Z"
+  },
+  {
+    "id": 1002605,
+    "number": 2,
+    "title": "1.4",
+    "state": "open",
+    "due_on": "2021-01-15T08:00:00Z"
+  },
+  {
+    "id": 1002606,
+    "number": 3,
+    "title": "Backlog",
+    "state": "open"
+  }
+]`
+
+// MockListMilestonesResponse returns a mock response for the list milestones call, with the milestones `1.3` (due
+// 2020-12-01), `1.4` (due 2021-01-15) and `Backlog` (no due date)
+func MockListMilestonesResponse() MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   listMilestonesResponse,
+	}
+}
+
+// MockCreateMilestoneResponse returns a mock response for the create milestone call of a milestone with the given
+// title
+func MockCreateMilestoneResponse(title string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusCreated,
+		Response:   fmt.Sprintf(`{"id": 1002607, "number": 4, "title": "%s", "state": "open"}`, title),
+	}
+}
+
+const listCheckRunsResponse = `{
+  "total_count": 3,
+  "check_runs": [
+    {"id": 4, "name": "lint", "status": "%s", "conclusion": "%s"},
+    {"id": 5, "name": "test", "status": "completed", "conclusion": "success"},
+    {"id": 6, "name": "codecov", "status": "completed", "conclusion": "failure"}
+  ]
+}`
+
+// MockListCheckRunsResponse returns a mock response for the list check runs call, with a `lint` check run of the
+// given status and conclusion, a successful `test` check run and a failed `codecov` check run
+func MockListCheckRunsResponse(lintStatus, lintConclusion string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response:   fmt.Sprintf(listCheckRunsResponse, lintStatus, lintConclusion),
+	}
+}
+
+// MockGetCombinedStatusResponse returns a mock response for the get combined status call, with a `ci/jenkins` status
+// of the given state
+func MockGetCombinedStatusResponse(state string) MockResponse {
+	return MockResponse{
+		StatusCode: http.StatusOK,
+		Response: fmt.Sprintf(`{"state": "%[1]s", "statuses": [{"context": "ci/jenkins", "state": "%[1]s"}]}`,
+			state),
+	}
+}
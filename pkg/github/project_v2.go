@@ -0,0 +1,258 @@
+package github
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// CurrentIteration is the value of an iteration field that stands for the iteration that includes the current date
+const CurrentIteration = "@current"
+
+// dateLayout is the layout of the date fields and the iteration start dates of the projects (v2)
+const dateLayout = "2006-01-02"
+
+// now returns the current time. It's a variable so that tests can fix the date of the current iteration
+var now = time.Now
+
+const projectV2Query = `query($owner: String!, $number: Int!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        id
+        fields(first: 100) {
+          nodes {
+            ... on ProjectV2FieldCommon {
+              id
+              name
+              dataType
+            }
+            ... on ProjectV2SingleSelectField {
+              options {
+                id
+                name
+              }
+            }
+            ... on ProjectV2IterationField {
+              configuration {
+                iterations {
+                  id
+                  title
+                  startDate
+                  duration
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const addProjectV2ItemMutation = `mutation($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) {
+    item {
+      id
+    }
+  }
+}`
+
+const updateProjectV2ItemFieldMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $value: ProjectV2FieldValue!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: $value}) {
+    projectV2Item {
+      id
+    }
+  }
+}`
+
+// ProjectV2 is the struct to represent a project (v2) and its fields
+type ProjectV2 struct {
+	ID string
+	// Fields holds the fields of the project keyed by their lower case name
+	Fields map[string]ProjectV2Field
+}
+
+// ProjectV2Field is the struct to represent a field of a project (v2). Options and Iterations are only set for
+// single select and iteration fields respectively
+type ProjectV2Field struct {
+	ID, Name, DataType string
+	Options            []ProjectV2Option
+	Iterations         []ProjectV2Iteration
+}
+
+// ProjectV2Option is the struct to represent an option of a single select field
+type ProjectV2Option struct {
+	ID, Name string
+}
+
+// ProjectV2Iteration is the struct to represent an iteration of an iteration field
+type ProjectV2Iteration struct {
+	ID        string
+	Title     string
+	StartDate string `json:"startDate"`
+	// Duration is the duration of the iteration in days
+	Duration int
+}
+
+// includes returns true if the given day is within the iteration
+func (it ProjectV2Iteration) includes(day time.Time) bool {
+	start, err := time.Parse(dateLayout, it.StartDate)
+	if err != nil {
+		return false
+	}
+	return !day.Before(start) && day.Before(start.AddDate(0, 0, it.Duration))
+}
+
+// value returns the GraphQL field value of the field for the given raw value, i.e. the option id of a single select
+// field, the iteration id of an iteration field, the number of a number field etc.
+func (f ProjectV2Field) value(raw string) (map[string]interface{}, error) {
+	switch f.DataType {
+	case "SINGLE_SELECT":
+		for _, o := range f.Options {
+			if strings.EqualFold(o.Name, raw) {
+				return map[string]interface{}{"singleSelectOptionId": o.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("no option found with name %s", raw)
+	case "ITERATION":
+		today := now().UTC().Truncate(24 * time.Hour)
+		for _, it := range f.Iterations {
+			if strings.EqualFold(it.Title, raw) || (raw == CurrentIteration && it.includes(today)) {
+				return map[string]interface{}{"iterationId": it.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("no iteration found with title %s", raw)
+	case "NUMBER":
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number", raw)
+		}
+		return map[string]interface{}{"number": number}, nil
+	case "DATE":
+		if _, err := time.Parse(dateLayout, raw); err != nil {
+			return nil, fmt.Errorf("%s is not a date of the format %s", raw, dateLayout)
+		}
+		return map[string]interface{}{"date": raw}, nil
+	case "TEXT":
+		return map[string]interface{}{"text": raw}, nil
+	}
+	return nil, fmt.Errorf("fields of type %s are not supported", f.DataType)
+}
+
+// GetProjectV2 returns the project (v2) of the given owner (an organization or a user) with the given number. An empty
+// owner stands for the owner of the repository
+func (r Repo) GetProjectV2(owner string, number int) (*ProjectV2, error) {
+	if owner == "" {
+		owner = r.Owner
+	}
+	var data struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				ID     string
+				Fields struct {
+					Nodes []struct {
+						ID, Name, DataType string
+						Options            []ProjectV2Option
+						Configuration      struct {
+							Iterations []ProjectV2Iteration
+						}
+					}
+				}
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	err := r.graphQL(projectV2Query, map[string]interface{}{"owner": owner, "number": number}, &data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get project (%s/%d). error message : %s", owner, number, err.Error())
+	}
+	if data.RepositoryOwner == nil || data.RepositoryOwner.ProjectV2 == nil {
+		return nil, fmt.Errorf("no project found with number %d for owner %s", number, owner)
+	}
+
+	project := &ProjectV2{ID: data.RepositoryOwner.ProjectV2.ID, Fields: map[string]ProjectV2Field{}}
+	for _, f := range data.RepositoryOwner.ProjectV2.Fields.Nodes {
+		project.Fields[strings.ToLower(f.Name)] = ProjectV2Field{
+			ID:         f.ID,
+			Name:       f.Name,
+			DataType:   f.DataType,
+			Options:    f.Options,
+			Iterations: f.Configuration.Iterations,
+		}
+	}
+	return project, nil
+}
+
+// AddToProjectV2 adds the issue to the given project (v2), if it's not already there, and sets the given field values
+// keyed by the field name (case insensitive). Single select and iteration values are the names of their options and
+// iterations respectively
+func (i Issue) AddToProjectV2(project *ProjectV2, fields map[string]string) error {
+	content, err := i.issueContent()
+	if err != nil {
+		return err
+	}
+	return i.addContentToProjectV2(project, content.NodeID, fields)
+}
+
+// AddPullRequestToProjectV2 adds the pull request to the given project (v2), if it's not already there, and sets the
+// given field values as AddToProjectV2 does
+func (i Issue) AddPullRequestToProjectV2(project *ProjectV2, fields map[string]string) error {
+	content, err := i.pullRequestContent()
+	if err != nil {
+		return err
+	}
+	return i.addContentToProjectV2(project, content.NodeID, fields)
+}
+
+// addContentToProjectV2 adds the content (an issue or a pull request) with the given node id to the given project
+// (v2) and sets the given field values
+func (i Issue) addContentToProjectV2(project *ProjectV2, contentID string, fields map[string]string) error {
+	log.Printf("Adding %s/%s#%d to project %s", i.Owner, i.Name, i.Number, project.ID)
+	var data struct {
+		AddProjectV2ItemByID struct {
+			Item struct {
+				ID string
+			}
+		} `json:"addProjectV2ItemById"`
+	}
+	err := i.graphQL(addProjectV2ItemMutation, map[string]interface{}{"project": project.ID, "content": contentID}, &data)
+	if err != nil {
+		return fmt.Errorf("cannot add issue (%d) to project (%s). error message : %s", i.Number, project.ID, err.Error())
+	}
+	itemID := data.AddProjectV2ItemByID.Item.ID
+
+	// fields are set in a stable order to keep the calls predictable
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	merr := new(multierror.Error)
+	for _, name := range names {
+		field, ok := project.Fields[strings.ToLower(name)]
+		if !ok {
+			merr = multierror.Append(merr, fmt.Errorf("cannot set field %s of issue (%d). error message : no project field found with name %s",
+				name, i.Number, name))
+			continue
+		}
+		value, err := field.value(fields[name])
+		if err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("cannot set field %s of issue (%d). error message : %s",
+				field.Name, i.Number, err.Error()))
+			continue
+		}
+		log.Printf("Setting field %s of %s/%s#%d to %s", field.Name, i.Owner, i.Name, i.Number, fields[name])
+		variables := map[string]interface{}{"project": project.ID, "item": itemID, "field": field.ID, "value": value}
+		if err := i.graphQL(updateProjectV2ItemFieldMutation, variables, nil); err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("cannot set field %s of issue (%d). error message : %s",
+				field.Name, i.Number, err.Error()))
+		}
+	}
+	return merr.ErrorOrNil()
+}
@@ -0,0 +1,264 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
+)
+
+func TestRepo_GetProjectV2(t *testing.T) {
+	tests := []struct {
+		name            string
+		responses       []MockResponse
+		wantErr         bool
+		expectedError   error
+		expectedID      string
+		expectedOptions []ProjectV2Option
+	}{
+		{
+			name:       "should return the project and its fields",
+			responses:  []MockResponse{MockGetProjectV2Response()},
+			expectedID: "PVT_kwDOAQ",
+			expectedOptions: []ProjectV2Option{
+				{ID: "f75ad846", Name: "Todo"},
+				{ID: "47fc9ee4", Name: "In Progress"},
+				{ID: "98236657", Name: "Done"},
+			},
+		},
+		{
+			name: "should error if the project doesn't exist",
+			responses: []MockResponse{
+				{StatusCode: http.StatusOK, Response: `{"data": {"repositoryOwner": {"projectV2": null}}}`},
+			},
+			wantErr:       true,
+			expectedError: errors.New("no project found with number 5 for owner octo-org"),
+		},
+		{
+			name:          "should error if the query fails",
+			responses:     []MockResponse{MockGraphQLErrorResponse("Could not resolve to a ProjectV2 with the number 5.")},
+			wantErr:       true,
+			expectedError: errors.New("cannot get project (octo-org/5). error message : Could not resolve to a ProjectV2 with the number 5."),
+		},
+		{
+			name:          "should error if the request fails",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get project (octo-org/5). error message : POST https://api.github.com/graphql: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			project, err := repo.GetProjectV2("octo-org", 5)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			if tt.wantErr {
+				return
+			}
+			if project.ID != tt.expectedID {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedID, project.ID)
+			}
+			if options := project.Fields["status"].Options; !reflect.DeepEqual(options, tt.expectedOptions) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedOptions, options)
+			}
+		})
+	}
+}
+
+func TestProjectV2Field_value(t *testing.T) {
+	field := ProjectV2Field{ID: "PVTIF_iteration", Name: "Iteration", DataType: "ITERATION", Iterations: []ProjectV2Iteration{
+		{ID: "cfc16e4d", Title: "Sprint 12", StartDate: "2020-12-14", Duration: 14},
+		{ID: "5a9b2c71", Title: "Sprint 13", StartDate: "2020-12-28", Duration: 14},
+	}}
+	tests := []struct {
+		name          string
+		today         string
+		raw           string
+		expected      map[string]interface{}
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:     "should return the current iteration",
+			today:    "2020-12-20",
+			raw:      CurrentIteration,
+			expected: map[string]interface{}{"iterationId": "cfc16e4d"},
+		},
+		{
+			name:     "should return the next iteration from its first day",
+			today:    "2020-12-28",
+			raw:      CurrentIteration,
+			expected: map[string]interface{}{"iterationId": "5a9b2c71"},
+		},
+		{
+			name:     "should return a past iteration by its title",
+			today:    "2021-01-02",
+			raw:      "sprint 12",
+			expected: map[string]interface{}{"iterationId": "cfc16e4d"},
+		},
+		{
+			name:          "should error if no iteration includes the current date",
+			today:         "2021-01-11",
+			raw:           CurrentIteration,
+			wantErr:       true,
+			expectedError: errors.New("no iteration found with title @current"),
+		},
+		{
+			name:          "should error if all the iterations are in the future",
+			today:         "2020-12-01",
+			raw:           CurrentIteration,
+			wantErr:       true,
+			expectedError: errors.New("no iteration found with title @current"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, err := time.Parse(dateLayout, tt.today)
+			if err != nil {
+				t.Fatal(err)
+			}
+			now = func() time.Time { return day.Add(15 * time.Hour) }
+			t.Cleanup(func() { now = time.Now })
+
+			actual, err := field.value(tt.raw)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIssue_AddToProjectV2(t *testing.T) {
+	project := &ProjectV2{
+		ID: "PVT_kwDOAQ",
+		Fields: map[string]ProjectV2Field{
+			"status": {ID: "PVTSSF_status", Name: "Status", DataType: "SINGLE_SELECT", Options: []ProjectV2Option{
+				{ID: "f75ad846", Name: "Todo"},
+			}},
+			"iteration": {ID: "PVTIF_iteration", Name: "Iteration", DataType: "ITERATION", Iterations: []ProjectV2Iteration{
+				{ID: "cfc16e4d", Title: "Sprint 12", StartDate: "2020-12-14", Duration: 14},
+			}},
+			"estimate": {ID: "PVTF_estimate", Name: "Estimate", DataType: "NUMBER"},
+			"team":     {ID: "PVTF_team", Name: "Team", DataType: "TEXT"},
+		},
+	}
+	tests := []struct {
+		name          string
+		fields        map[string]string
+		responses     []MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should add the issue to the project",
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockAddProjectV2ItemResponse(),
+			},
+		},
+		{
+			name:   "should add the issue to the project and set its fields",
+			fields: map[string]string{"status": "todo", "Iteration": "Sprint 12", "Estimate": "3", "Team": "infra"},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockAddProjectV2ItemResponse(),
+				MockUpdateProjectV2ItemFieldResponse(),
+				MockUpdateProjectV2ItemFieldResponse(),
+				MockUpdateProjectV2ItemFieldResponse(),
+				MockUpdateProjectV2ItemFieldResponse(),
+			},
+		},
+		{
+			name:   "should error if a field or a value doesn't exist",
+			fields: map[string]string{"Size": "XL", "Status": "Blocked", "Estimate": "many", "Team": "infra"},
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockAddProjectV2ItemResponse(),
+				MockUpdateProjectV2ItemFieldResponse(),
+			},
+			wantErr: true,
+			expectedError: errors.New("3 errors occurred:\n" +
+				"\t* cannot set field Estimate of issue (1347). error message : many is not a number\n" +
+				"\t* cannot set field Size of issue (1347). error message : no project field found with name Size\n" +
+				"\t* cannot set field Status of issue (1347). error message : no option found with name Blocked\n\n"),
+		},
+		{
+			name: "should error if the issue cannot be added to the project",
+			responses: []MockResponse{
+				MockGetIssueResponse(),
+				MockGraphQLErrorResponse("Resource not accessible by integration"),
+			},
+			wantErr:       true,
+			expectedError: errors.New("cannot add issue (1347) to project (PVT_kwDOAQ). error message : Resource not accessible by integration"),
+		},
+		{
+			name:          "should error if the issue cannot be fetched",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get issue with number 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/issues/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347)
+			err := issue.AddToProjectV2(project, tt.fields)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestIssue_AddPullRequestToProjectV2(t *testing.T) {
+	project := &ProjectV2{
+		ID: "PVT_kwDOAQ",
+		Fields: map[string]ProjectV2Field{
+			"status": {ID: "PVTSSF_status", Name: "Status", DataType: "SINGLE_SELECT", Options: []ProjectV2Option{
+				{ID: "47fc9ee4", Name: "In Progress"},
+			}},
+		},
+	}
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should add the pull request to the project and set its status",
+			responses: []MockResponse{
+				MockGetPullRequestResponse("true"),
+				MockAddProjectV2ItemResponse(),
+				MockUpdateProjectV2ItemFieldResponse(),
+			},
+		},
+		{
+			name:          "should error if the pull request cannot be fetched",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get pull request with number 1347. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls/1347: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := NewIssue(Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}, 1347)
+			err := issue.AddPullRequestToProjectV2(project, map[string]string{"Status": "In Progress"})
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
@@ -2,11 +2,17 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/google/go-github/v27/github"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/slices"
 )
 
 // Repo is the struct to represent a github repository
@@ -49,6 +55,12 @@ func (r Repo) LoadFile(file, sha string) (*[]byte, error) {
 	return &raw, nil
 }
 
+// IsNotFound returns true if the given error, or any error it wraps, is a github api error with a 404 status code
+func IsNotFound(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
+
 // GetProjectID returns the id of a project given its url
 func (r Repo) GetProjectID(projectURL string) (int64, error) {
 	projects, _, err := r.GHClient.Repositories.ListProjects(context.Background(), r.Owner, r.Name, &github.ProjectListOptions{})
@@ -79,3 +91,230 @@ func (r Repo) GetProjectID(projectURL string) (int64, error) {
 
 	return projectID, nil
 }
+
+// CreateStatus creates a commit status for the given sha with the given state (`error`, `failure`, `pending` or
+// `success`), context and description
+func (r Repo) CreateStatus(sha, state, statusContext, description string) error {
+	log.Printf("Setting status %s of %s/%s@%s to %s: %s", statusContext, r.Owner, r.Name, sha, state, description)
+	_, _, err := r.GHClient.Repositories.CreateStatus(context.Background(), r.Owner, r.Name, sha, &github.RepoStatus{
+		State:       &state,
+		Context:     &statusContext,
+		Description: &description,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create status %s for %s. error message : %s", statusContext, sha, err.Error())
+	}
+	return nil
+}
+
+// Labels returns all the labels of the repository
+func (r Repo) Labels() ([]*github.Label, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var labels []*github.Label
+	for {
+		page, resp, err := r.GHClient.Issues.ListLabels(context.Background(), r.Owner, r.Name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get repository (%s/%s) labels. error message : %s", r.Owner, r.Name, err.Error())
+		}
+		labels = append(labels, page...)
+		if resp.NextPage == 0 {
+			return labels, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateLabel creates a new repository label
+func (r Repo) CreateLabel(name, color, description string) error {
+	log.Printf("Creating label %s in %s/%s", name, r.Owner, r.Name)
+	_, _, err := r.GHClient.Issues.CreateLabel(context.Background(), r.Owner, r.Name, &github.Label{
+		Name:        &name,
+		Color:       &color,
+		Description: &description,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create label %s. error message : %s", name, err.Error())
+	}
+	return nil
+}
+
+// EditLabel updates the name, color and description of an existing repository label
+func (r Repo) EditLabel(currentName, name, color, description string) error {
+	log.Printf("Updating label %s in %s/%s", currentName, r.Owner, r.Name)
+	// go-github doesn't escape the label name of the URL path, so names like `size/XS` would hit the wrong URL
+	_, _, err := r.GHClient.Issues.EditLabel(context.Background(), r.Owner, r.Name, url.PathEscape(currentName), &github.Label{
+		Name:        &name,
+		Color:       &color,
+		Description: &description,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot update label %s. error message : %s", currentName, err.Error())
+	}
+	return nil
+}
+
+// DeleteLabel deletes a repository label
+func (r Repo) DeleteLabel(name string) error {
+	log.Printf("Deleting label %s from %s/%s", name, r.Owner, r.Name)
+	_, err := r.GHClient.Issues.DeleteLabel(context.Background(), r.Owner, r.Name, url.PathEscape(name))
+	if err != nil {
+		return fmt.Errorf("cannot delete label %s. error message : %s", name, err.Error())
+	}
+	return nil
+}
+
+// PullRequestsWithCommit returns the pull requests associated with the given commit, i.e. the ones whose branches
+// contain it
+func (r Repo) PullRequestsWithCommit(sha string) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var pullRequests []*github.PullRequest
+	for {
+		page, resp, err := r.GHClient.PullRequests.ListPullRequestsWithCommit(
+			context.Background(), r.Owner, r.Name, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get pull requests of commit %s. error message : %s", sha, err.Error())
+		}
+		pullRequests = append(pullRequests, page...)
+		if resp.NextPage == 0 {
+			return pullRequests, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CheckRuns returns the latest check runs of the given commit
+func (r Repo) CheckRuns(sha string) ([]*github.CheckRun, error) {
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var checkRuns []*github.CheckRun
+	for {
+		page, resp, err := r.GHClient.Checks.ListCheckRunsForRef(context.Background(), r.Owner, r.Name, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get check runs of commit %s. error message : %s", sha, err.Error())
+		}
+		checkRuns = append(checkRuns, page.CheckRuns...)
+		if resp.NextPage == 0 {
+			return checkRuns, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Statuses returns the latest status of each context of the given commit
+func (r Repo) Statuses(sha string) ([]github.RepoStatus, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var statuses []github.RepoStatus
+	for {
+		combined, resp, err := r.GHClient.Repositories.GetCombinedStatus(context.Background(), r.Owner, r.Name, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get statuses of commit %s. error message : %s", sha, err.Error())
+		}
+		statuses = append(statuses, combined.Statuses...)
+		if resp.NextPage == 0 {
+			return statuses, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// OpenPullRequests returns all the open pull requests that target the given base branch
+func (r Repo) OpenPullRequests(base string) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{State: "open", Base: base, ListOptions: github.ListOptions{PerPage: 100}}
+	var pullRequests []*github.PullRequest
+	for {
+		page, resp, err := r.GHClient.PullRequests.List(context.Background(), r.Owner, r.Name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get open pull requests of branch %s. error message : %s", base, err.Error())
+		}
+		pullRequests = append(pullRequests, page...)
+		if resp.NextPage == 0 {
+			return pullRequests, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Milestones returns all the milestones of the repository with the given state (`open`, `closed` or `all`), sorted by
+// their due date
+func (r Repo) Milestones(state string) ([]*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{State: state, ListOptions: github.ListOptions{PerPage: 100}}
+	var milestones []*github.Milestone
+	for {
+		page, resp, err := r.GHClient.Issues.ListMilestones(context.Background(), r.Owner, r.Name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get repository (%s/%s) milestones. error message : %s", r.Owner, r.Name, err.Error())
+		}
+		milestones = append(milestones, page...)
+		if resp.NextPage == 0 {
+			return milestones, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateMilestone creates an open milestone with the given title and returns it
+func (r Repo) CreateMilestone(title string) (*github.Milestone, error) {
+	log.Printf("Creating milestone %s in %s/%s", title, r.Owner, r.Name)
+	milestone, _, err := r.GHClient.Issues.CreateMilestone(context.Background(), r.Owner, r.Name, &github.Milestone{Title: &title})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create milestone %s. error message : %s", title, err.Error())
+	}
+	return milestone, nil
+}
+
+// baseRoles are the repository roles defined by GitHub, as opposed to the custom roles of organizations
+var baseRoles = slices.StringSlice{"read", "triage", "write", "maintain", "admin"}
+
+// PermissionLevel returns the role (`admin`, `maintain`, `write`, `triage`, `read` or `none`) of the given user on the
+// repository. The legacy permission of the api only knows `admin`, `write`, `read` and `none` (maintainers are `write`
+// and triagers are `read`) so the role name is used instead. go-github doesn't expose the role name so the response is
+// decoded here. Custom roles fall back to the legacy permission they are based on
+func (r Repo) PermissionLevel(user string) (string, error) {
+	u := fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", r.Owner, r.Name, user)
+	req, err := r.GHClient.NewRequest("GET", u, nil)
+	if err == nil {
+		level := &struct {
+			Permission string `json:"permission"`
+			RoleName   string `json:"role_name"`
+		}{}
+		_, err = r.GHClient.Do(context.Background(), req, level)
+		if err == nil {
+			if baseRoles.HasString(level.RoleName) {
+				return level.RoleName, nil
+			}
+			return level.Permission, nil
+		}
+	}
+	return "", fmt.Errorf("cannot get the permission of %s on %s/%s. error message : %s", user, r.Owner, r.Name, err.Error())
+}
+
+// TeamMembers returns the logins of the members of the given organization team
+func (r Repo) TeamMembers(org, slug string) ([]string, error) {
+	team, _, err := r.GHClient.Teams.GetTeamBySlug(context.Background(), org, slug)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get team %s/%s. error message : %s", org, slug, err.Error())
+	}
+	opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var members []string
+	for {
+		users, resp, err := r.GHClient.Teams.ListTeamMembers(context.Background(), team.GetID(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get members of team %s/%s. error message : %s", org, slug, err.Error())
+		}
+		for _, user := range users {
+			members = append(members, user.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return members, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// IsOrgMember returns true if the given user is a member of the given organization
+func (r Repo) IsOrgMember(org, user string) (bool, error) {
+	isMember, _, err := r.GHClient.Organizations.IsMember(context.Background(), org, user)
+	if err != nil {
+		return false, fmt.Errorf("cannot check if %s is a member of %s. error message : %s", user, org, err.Error())
+	}
+	return isMember, nil
+}
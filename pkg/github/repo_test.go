@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-github/v27/github"
+
 	"github.com/ppapapetrou76/virtual-assistant/pkg/testutil"
 )
 
@@ -113,3 +115,549 @@ func TestRepo_LoadFile(t *testing.T) {
 		})
 	}
 }
+
+func TestRepo_CreateStatus(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should create the status",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockGenericSuccessResponse(),
+				}),
+			},
+		},
+		{
+			name: "should error if the status cannot be created",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("cannot create status some-context for abc. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/statuses/abc: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			err := repo.CreateStatus("abc", "success", "some-context", "some description")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+		})
+	}
+}
+
+func TestRepo_Labels(t *testing.T) {
+	type fields struct {
+		ghClient ClientWrapper
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		wantErr        bool
+		expectedError  error
+		expectedLabels []string
+	}{
+		{
+			name: "should return the labels of all pages",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					MockNextPage(MockListRepositoryLabelsResponse(), 2),
+					MockListRepositoryLabelsResponse(),
+				}),
+			},
+			expectedLabels: []string{"bug", "enhancement", "bug", "enhancement"},
+		},
+		{
+			name: "should error if the labels cannot be retrieved",
+			fields: fields{
+				ghClient: MockGithubClient([]MockResponse{
+					UnAuthorizedMockResponse(),
+				}),
+			},
+			expectedError: errors.New("cannot get repository (ppapapetrou76/virtual-assistant) labels. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/labels?per_page=100: 401 Bad credentials []"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: tt.fields.ghClient,
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			labels, err := repo.Labels()
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			var actualLabels []string
+			for _, l := range labels {
+				actualLabels = append(actualLabels, l.GetName())
+			}
+			if !reflect.DeepEqual(actualLabels, tt.expectedLabels) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedLabels, actualLabels)
+			}
+		})
+	}
+}
+
+func TestRepo_ManageLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		call          func(r Repo) error
+		response      MockResponse
+		expectedPath  string
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:     "should create a label",
+			call:     func(r Repo) error { return r.CreateLabel("bug", "d73a4a", "Something isn't working") },
+			response: MockGenericSuccessResponse(),
+		},
+		{
+			name:          "should error if a label cannot be created",
+			call:          func(r Repo) error { return r.CreateLabel("bug", "d73a4a", "Something isn't working") },
+			response:      UnAuthorizedMockResponse(),
+			wantErr:       true,
+			expectedError: errors.New("cannot create label bug. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/labels: 401 Bad credentials []"),
+		},
+		{
+			name:     "should update a label",
+			call:     func(r Repo) error { return r.EditLabel("defect", "bug", "d73a4a", "Something isn't working") },
+			response: MockGenericSuccessResponse(),
+		},
+		{
+			name:         "should escape the name of the updated label",
+			call:         func(r Repo) error { return r.EditLabel("size/XS", "size/S", "ededed", "") },
+			response:     MockGenericSuccessResponse(),
+			expectedPath: "/repos/ppapapetrou76/virtual-assistant/labels/size%2FXS",
+		},
+		{
+			name:          "should error if a label cannot be updated",
+			call:          func(r Repo) error { return r.EditLabel("defect", "bug", "d73a4a", "Something isn't working") },
+			response:      UnAuthorizedMockResponse(),
+			wantErr:       true,
+			expectedError: errors.New("cannot update label defect. error message : PATCH https://api.github.com/repos/ppapapetrou76/virtual-assistant/labels/defect: 401 Bad credentials []"),
+		},
+		{
+			name:     "should delete a label",
+			call:     func(r Repo) error { return r.DeleteLabel("bug") },
+			response: MockGenericSuccessResponse(),
+		},
+		{
+			name:         "should escape the name of the deleted label",
+			call:         func(r Repo) error { return r.DeleteLabel("priority: 100%") },
+			response:     MockGenericSuccessResponse(),
+			expectedPath: "/repos/ppapapetrou76/virtual-assistant/labels/priority:%20100%25",
+		},
+		{
+			name:          "should error if a label cannot be deleted",
+			call:          func(r Repo) error { return r.DeleteLabel("bug") },
+			response:      UnAuthorizedMockResponse(),
+			wantErr:       true,
+			expectedError: errors.New("cannot delete label bug. error message : DELETE https://api.github.com/repos/ppapapetrou76/virtual-assistant/labels/bug: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tripper := &MockRoundTripper{Responses: []MockResponse{tt.response}}
+			repo := Repo{
+				GHClient: Client(NewTestClient(tripper)),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			err := tt.call(repo)
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if actual := tripper.Requests[0].URL.EscapedPath(); tt.expectedPath != "" && actual != tt.expectedPath {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedPath, actual)
+			}
+		})
+	}
+}
+
+func TestRepo_IsOrgMember(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      MockResponse
+		expected      bool
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:     "should return true if the user is a member",
+			response: MockResponse{StatusCode: http.StatusNoContent},
+			expected: true,
+		},
+		{
+			name:     "should return false if the user is not a member",
+			response: MockResponse{StatusCode: http.StatusNotFound, Response: `{"message": "Not Found"}`},
+		},
+		{
+			name:          "should error if the membership cannot be checked",
+			response:      UnAuthorizedMockResponse(),
+			wantErr:       true,
+			expectedError: errors.New("cannot check if octocat is a member of myorg. error message : GET https://api.github.com/orgs/myorg/members/octocat: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient([]MockResponse{tt.response}),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			actual, err := repo.IsOrgMember("myorg", "octocat")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRepo_PullRequestsWithCommit(t *testing.T) {
+	tests := []struct {
+		name            string
+		responses       []MockResponse
+		wantErr         bool
+		expectedError   error
+		expectedNumbers []int
+	}{
+		{
+			name: "should return the pull requests of all pages",
+			responses: []MockResponse{
+				MockNextPage(MockListPullRequestsWithCommitResponse(), 2),
+				MockListPullRequestsWithCommitResponse(),
+			},
+			expectedNumbers: []int{1347, 1348, 1200, 1347, 1348, 1200},
+		},
+		{
+			name:          "should error if the pull requests cannot be retrieved",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get pull requests of commit 6dcb09b. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/commits/6dcb09b/pulls?per_page=100: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			pullRequests, err := repo.PullRequestsWithCommit("6dcb09b")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			var actualNumbers []int
+			for _, pr := range pullRequests {
+				actualNumbers = append(actualNumbers, pr.GetNumber())
+			}
+			if !reflect.DeepEqual(actualNumbers, tt.expectedNumbers) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedNumbers, actualNumbers)
+			}
+		})
+	}
+}
+
+func TestRepo_CheckRunsAndStatuses(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		expected      []string
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should return the check runs and the statuses of all pages",
+			responses: []MockResponse{
+				MockNextPage(MockListCheckRunsResponse("completed", "success"), 2),
+				MockListCheckRunsResponse("completed", "success"),
+				MockGetCombinedStatusResponse("success"),
+			},
+			expected: []string{"lint", "test", "codecov", "lint", "test", "codecov", "ci/jenkins"},
+		},
+		{
+			name:          "should error if the check runs cannot be retrieved",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get check runs of commit 6dcb09b. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/commits/6dcb09b/check-runs?per_page=100: 401 Bad credentials []"),
+		},
+		{
+			name:          "should error if the statuses cannot be retrieved",
+			responses:     []MockResponse{MockListCheckRunsResponse("completed", "success"), UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get statuses of commit 6dcb09b. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/commits/6dcb09b/status?per_page=100: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			var actual []string
+			checkRuns, err := repo.CheckRuns("6dcb09b")
+			if err == nil {
+				for _, run := range checkRuns {
+					actual = append(actual, run.GetName())
+				}
+				var statuses []github.RepoStatus
+				statuses, err = repo.Statuses("6dcb09b")
+				for _, status := range statuses {
+					actual = append(actual, status.GetContext())
+				}
+			}
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !tt.wantErr && !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRepo_OpenPullRequests(t *testing.T) {
+	tests := []struct {
+		name            string
+		responses       []MockResponse
+		wantErr         bool
+		expectedError   error
+		expectedNumbers []int
+	}{
+		{
+			name: "should return the pull requests of all pages",
+			responses: []MockResponse{
+				MockNextPage(MockListPullRequestsWithCommitResponse(), 2),
+				MockListPullRequestsWithCommitResponse(),
+			},
+			expectedNumbers: []int{1347, 1348, 1200, 1347, 1348, 1200},
+		},
+		{
+			name:          "should error if the pull requests cannot be retrieved",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get open pull requests of branch master. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/pulls?base=master&per_page=100&state=open: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			pullRequests, err := repo.OpenPullRequests("master")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			var actualNumbers []int
+			for _, pr := range pullRequests {
+				actualNumbers = append(actualNumbers, pr.GetNumber())
+			}
+			if !reflect.DeepEqual(actualNumbers, tt.expectedNumbers) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expectedNumbers, actualNumbers)
+			}
+		})
+	}
+}
+
+func TestRepo_PermissionLevel(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      MockResponse
+		expected      string
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:     "should return the role of the user",
+			response: MockGetPermissionLevelResponse("write", "maintain"),
+			expected: "maintain",
+		},
+		{
+			name:     "should return the role of a triager",
+			response: MockGetPermissionLevelResponse("read", "triage"),
+			expected: "triage",
+		},
+		{
+			name:     "should return the permission of a custom role",
+			response: MockGetPermissionLevelResponse("write", "security-manager"),
+			expected: "write",
+		},
+		{
+			name:     "should return the permission if there is no role",
+			response: MockGetPermissionLevelResponse("read", ""),
+			expected: "read",
+		},
+		{
+			name:          "should error if the permission cannot be retrieved",
+			response:      UnAuthorizedMockResponse(),
+			wantErr:       true,
+			expectedError: errors.New("cannot get the permission of octocat on ppapapetrou76/virtual-assistant. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/collaborators/octocat/permission: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient([]MockResponse{tt.response}),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			actual, err := repo.PermissionLevel("octocat")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRepo_TeamMembers(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		expected      []string
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should return the members of all pages",
+			responses: []MockResponse{
+				MockGetTeamResponse(),
+				MockNextPage(MockListTeamMembersResponse(), 2),
+				MockListTeamMembersResponse(),
+			},
+			expected: []string{"octocat", "monalisa", "octocat", "monalisa"},
+		},
+		{
+			name:          "should error if the team cannot be retrieved",
+			responses:     []MockResponse{MockNotFoundResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get team myorg/backend. error message : GET https://api.github.com/orgs/myorg/teams/backend: 404 Not Found []"),
+		},
+		{
+			name:          "should error if the members cannot be retrieved",
+			responses:     []MockResponse{MockGetTeamResponse(), UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get members of team myorg/backend. error message : GET https://api.github.com/teams/42/members?per_page=100: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			actual, err := repo.TeamMembers("myorg", "backend")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRepo_Milestones(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		expected      []string
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name: "should return the milestones of all pages",
+			responses: []MockResponse{
+				MockNextPage(MockListMilestonesResponse(), 2),
+				MockListMilestonesResponse(),
+			},
+			expected: []string{"1.3", "1.4", "Backlog", "1.3", "1.4", "Backlog"},
+		},
+		{
+			name:          "should error if the milestones cannot be retrieved",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot get repository (ppapapetrou76/virtual-assistant) milestones. error message : GET https://api.github.com/repos/ppapapetrou76/virtual-assistant/milestones?per_page=100&state=all: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			milestones, err := repo.Milestones("all")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+
+			var actual []string
+			for _, m := range milestones {
+				actual = append(actual, m.GetTitle())
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRepo_CreateMilestone(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []MockResponse
+		expected      int
+		wantErr       bool
+		expectedError error
+	}{
+		{
+			name:      "should create the milestone",
+			responses: []MockResponse{MockCreateMilestoneResponse("1.5")},
+			expected:  4,
+		},
+		{
+			name:          "should error if the milestone cannot be created",
+			responses:     []MockResponse{UnAuthorizedMockResponse()},
+			wantErr:       true,
+			expectedError: errors.New("cannot create milestone 1.5. error message : POST https://api.github.com/repos/ppapapetrou76/virtual-assistant/milestones: 401 Bad credentials []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := Repo{
+				GHClient: MockGithubClient(tt.responses),
+				Owner:    "ppapapetrou76",
+				Name:     "virtual-assistant",
+			}
+			milestone, err := repo.CreateMilestone("1.5")
+			testutil.AssertError(t, tt.wantErr, tt.expectedError, err)
+			if actual := milestone.GetNumber(); actual != tt.expected {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	repo := Repo{
+		GHClient: MockGithubClient([]MockResponse{MockNotFoundResponse(), UnAuthorizedMockResponse()}),
+		Owner:    "ppapapetrou76",
+		Name:     "virtual-assistant",
+	}
+	if _, err := repo.LoadFile("CODEOWNERS", ""); !IsNotFound(err) {
+		t.Errorf("Expect a not found error Got: \n%+v", err)
+	}
+	if _, err := repo.LoadFile("CODEOWNERS", ""); IsNotFound(err) {
+		t.Errorf("Expect an error other than not found Got: \n%+v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package codeowners
+
+import (
+	"strings"
+
+	"github.com/ppapapetrou76/virtual-assistant/pkg/util/glob"
+)
+
+// Rule is the struct to represent a line of a CODEOWNERS file, i.e. a file pattern and its owners (`@user`,
+// `@org/team` or email addresses)
+type Rule struct {
+	Pattern string
+	Owners  []string
+}
+
+// Rules is the list of rules of a CODEOWNERS file in the order they are declared
+type Rules []Rule
+
+// Parse parses the content of a CODEOWNERS file. Empty lines and comments are skipped
+func Parse(content string) Rules {
+	var rules Rules
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:]})
+	}
+	return rules
+}
+
+// Owners returns the owners of the given path. As in GitHub the last matching rule wins, so a matching rule without
+// owners means that the path has no owners
+func (r Rules) Owners(path string) []string {
+	for i := len(r) - 1; i >= 0; i-- {
+		if matches(r[i].Pattern, path) {
+			return r[i].Owners
+		}
+	}
+	return nil
+}
+
+// matches returns true if the given path matches the given CODEOWNERS pattern. Patterns follow the gitignore rules:
+// patterns starting with or containing a `/` are relative to the repository root while the others match at any depth,
+// and patterns ending with a `/` or naming a folder match everything under it
+func matches(pattern, path string) bool {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	folder := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "/"), "/")
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+	if folder {
+		return glob.Match(pattern+"/**", path)
+	}
+	if glob.Match(pattern, path) {
+		return true
+	}
+	// a pattern without wildcards in its last segment may name a folder too
+	last := pattern[strings.LastIndex(pattern, "/")+1:]
+	return !strings.ContainsAny(last, "*?") && glob.Match(pattern+"/**", path)
+}
@@ -0,0 +1,62 @@
+package codeowners
+
+import (
+	"reflect"
+	"testing"
+)
+
+const codeOwners = `# This is a comment
+*       @global-owner1 @global-owner2
+
+*.js    @js-owner # inline comment
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+**/logs @monalisa
+/scripts/ @doctocat @octocat
+/vendor/
+`
+
+func TestParse(t *testing.T) {
+	expected := Rules{
+		{Pattern: "*", Owners: []string{"@global-owner1", "@global-owner2"}},
+		{Pattern: "*.js", Owners: []string{"@js-owner"}},
+		{Pattern: "/build/logs/", Owners: []string{"@doctocat"}},
+		{Pattern: "docs/*", Owners: []string{"docs@example.com"}},
+		{Pattern: "apps/", Owners: []string{"@octocat"}},
+		{Pattern: "**/logs", Owners: []string{"@monalisa"}},
+		{Pattern: "/scripts/", Owners: []string{"@doctocat", "@octocat"}},
+		{Pattern: "/vendor/", Owners: []string{}},
+	}
+	actual := Parse(codeOwners)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expect: \n%+v Got: \n%+v", expected, actual)
+	}
+}
+
+func TestRules_Owners(t *testing.T) {
+	rules := Parse(codeOwners)
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "README.md", expected: []string{"@global-owner1", "@global-owner2"}},
+		{path: "web/app.js", expected: []string{"@js-owner"}},
+		{path: "build/logs/today.log", expected: []string{"@monalisa"}},
+		{path: "docs/getting-started.md", expected: []string{"docs@example.com"}},
+		{path: "docs/build-app/troubleshooting.md", expected: []string{"@global-owner1", "@global-owner2"}},
+		{path: "apps/main.go", expected: []string{"@octocat"}},
+		{path: "src/apps/main.go", expected: []string{"@octocat"}},
+		{path: "deep/logs/today.log", expected: []string{"@monalisa"}},
+		{path: "scripts/build.sh", expected: []string{"@doctocat", "@octocat"}},
+		{path: "vendor/lib/lib.go", expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			actual := rules.Owners(tt.path)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package glob
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Match returns true if the given path matches the glob pattern.
+// A single `*` matches any sequence of characters except `/`, `?` matches a single character except `/` and `**`
+// matches any sequence of characters including `/` so that `docs/**` matches every file under the docs folder.
+func Match(pattern, path string) bool {
+	re, err := regexp.Compile(toRegexp(pattern))
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
+
+// MatchAny returns true if the given path matches any of the given glob patterns
+func MatchAny(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if Match(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func toRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(.*/)?")
+					continue
+				}
+				sb.WriteString(".*")
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
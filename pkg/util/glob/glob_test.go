@@ -0,0 +1,99 @@
+package glob
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	type args struct {
+		pattern string
+		path    string
+	}
+	tests := []struct {
+		name     string
+		expected bool
+		args     args
+	}{
+		{
+			name:     "should match a file in the root folder",
+			expected: true,
+			args:     args{pattern: "*.md", path: "README.md"},
+		},
+		{
+			name: "should not match a nested file with a single star",
+			args: args{pattern: "*.md", path: "docs/README.md"},
+		},
+		{
+			name:     "should match nested files with a double star",
+			expected: true,
+			args:     args{pattern: "docs/**", path: "docs/guides/install.md"},
+		},
+		{
+			name:     "should match files in any folder with a leading double star",
+			expected: true,
+			args:     args{pattern: "**/*.go", path: "pkg/github/issue.go"},
+		},
+		{
+			name:     "should match root files with a leading double star",
+			expected: true,
+			args:     args{pattern: "**/*.go", path: "main.go"},
+		},
+		{
+			name:     "should match a single character",
+			expected: true,
+			args:     args{pattern: "v?.txt", path: "v1.txt"},
+		},
+		{
+			name: "should not match a different folder",
+			args: args{pattern: "docs/**", path: "pkg/docs.go"},
+		},
+		{
+			name:     "should escape regular expression characters",
+			expected: true,
+			args:     args{pattern: "go.(mod)", path: "go.(mod)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := Match(tt.args.pattern, tt.args.path)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	type args struct {
+		patterns []string
+		path     string
+	}
+	tests := []struct {
+		name     string
+		expected bool
+		args     args
+	}{
+		{
+			name:     "should return true if any pattern matches",
+			expected: true,
+			args:     args{patterns: []string{"docs/**", "*.md"}, path: "CHANGELOG.md"},
+		},
+		{
+			name: "should return false if no pattern matches",
+			args: args{patterns: []string{"docs/**", "*.md"}, path: "cmd/action.go"},
+		},
+		{
+			name: "should return false if there are no patterns",
+			args: args{path: "cmd/action.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := MatchAny(tt.args.patterns, tt.args.path)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
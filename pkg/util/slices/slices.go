@@ -32,3 +32,14 @@ func (ss StringSlice) ContainsAny(elements ...string) bool {
 func (ss StringSlice) Add(e string) StringSlice {
 	return append(ss, e)
 }
+
+// Remove returns a new string slice without any of the given elements
+func (ss StringSlice) Remove(elements ...string) StringSlice {
+	result := make(StringSlice, 0, len(ss))
+	for _, item := range ss {
+		if !StringSlice(elements).HasString(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
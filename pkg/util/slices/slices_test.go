@@ -103,3 +103,39 @@ func TestStringSlice_ContainsAny(t *testing.T) {
 		})
 	}
 }
+
+func TestStringSlice_Remove(t *testing.T) {
+	type fields struct {
+		slice StringSlice
+	}
+	type args struct {
+		elements StringSlice
+	}
+	tests := []struct {
+		name     string
+		expected StringSlice
+		fields   fields
+		args     args
+	}{
+		{
+			name:     "should remove the given elements",
+			fields:   fields{slice: StringSlice{"value", "anothervalue", "random"}},
+			expected: StringSlice{"anothervalue"},
+			args:     args{elements: StringSlice{"value", "random"}},
+		},
+		{
+			name:     "should return the same elements if none of the given elements exist",
+			fields:   fields{slice: StringSlice{"value", "anothervalue"}},
+			expected: StringSlice{"value", "anothervalue"},
+			args:     args{elements: StringSlice{"random"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.fields.slice.Remove(tt.args.elements...)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expect: \n%+v Got: \n%+v", tt.expected, actual)
+			}
+		})
+	}
+}